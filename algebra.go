@@ -0,0 +1,186 @@
+package semver
+
+import (
+	"slices"
+	"sort"
+)
+
+// Intersect returns a new Constraint that allows all versions allowed by both a and b.
+// The result is normalized into a RangeSet.
+// Constraints without overlap result in an empty RangeSet that allows no version.
+func Intersect(a, b Constraint) RangeSet {
+	return intersectRanges(constraintRanges(a), constraintRanges(b))
+}
+
+// Union returns a new Constraint that allows all versions allowed by either a or b.
+// The result is normalized into a RangeSet.
+func Union(a, b Constraint) RangeSet {
+	return unionRanges(append(constraintRanges(a), constraintRanges(b)...))
+}
+
+// Complement returns a new Constraint that allows all versions NOT allowed by a.
+// The result is normalized into a RangeSet.
+// Inclusive bounds turn into exclusive bounds and vice versa:
+// the complement of 1.2.0 - 1.4.0 is <1.2.0 || >1.4.0.
+func Complement(a Constraint) RangeSet {
+	return complementRanges(constraintRanges(a))
+}
+
+// range containing all versions.
+var fullRange = Range{MinUnbounded: true, MaxUnbounded: true}
+
+// constraintRanges flattens a Constraint into a list of sorted disjoint ranges.
+// Constraint implementations outside of this package are not supported
+// and result in an empty list.
+func constraintRanges(c Constraint) []Range {
+	switch v := unwrapConstraint(c).(type) {
+	case *Range:
+		if v.isEmpty() {
+			return nil
+		}
+		return []Range{canonicalRange(*v)}
+
+	case RangeSet:
+		return slices.Clone(v)
+
+	case not:
+		return complementRanges(constraintRanges(&v.Range))
+
+	case and:
+		out := []Range{fullRange}
+		for _, ac := range v {
+			out = intersectRanges(out, constraintRanges(ac))
+		}
+		return out
+
+	case or:
+		var out []Range
+		for _, oc := range v {
+			out = append(out, constraintRanges(oc)...)
+		}
+		return unionRanges(out)
+	}
+	return nil
+}
+
+// intersectRanges intersects two lists of sorted disjoint ranges.
+func intersectRanges(a, b []Range) []Range {
+	var out []Range
+	for _, ra := range a {
+		for _, rb := range b {
+			if !rangesOverlap(ra, rb) {
+				continue
+			}
+			r := ra
+			if compareLower(rb.lower(), r.lower()) > 0 {
+				r.setLower(rb.lower())
+			}
+			if compareUpper(rb.upper(), r.upper()) < 0 {
+				r.setUpper(rb.upper())
+			}
+			out = append(out, r)
+		}
+	}
+	sort.Sort(AscendingMin(out))
+	return out
+}
+
+// unionRanges merges overlapping and adjacent ranges
+// into a list of sorted disjoint ranges.
+func unionRanges(rs []Range) []Range {
+	c := make(or, 0, len(rs))
+	for i := range rs {
+		if rs[i].isEmpty() {
+			continue
+		}
+		c = append(c, &rs[i])
+	}
+	if len(c) == 0 {
+		return nil
+	}
+
+	c = compactLogicalOR(c)
+	out := make([]Range, len(c))
+	for i := range c {
+		out[i] = *c[i].(*Range)
+	}
+	return out
+}
+
+// complementRanges returns the gaps between a list of sorted disjoint ranges.
+func complementRanges(rs []Range) []Range {
+	var (
+		out []Range
+		// lower bound of the next gap
+		cursor = fullRange.lower()
+	)
+	for _, r := range rs {
+		if !r.MinUnbounded {
+			var gap Range
+			gap.setLower(cursor)
+			gap.setUpper(bound{v: r.Min, exclusive: !r.MinExclusive, upper: true})
+			if !gap.isEmpty() {
+				out = append(out, gap)
+			}
+		}
+		if r.MaxUnbounded {
+			return out
+		}
+		cursor = bound{v: r.Max, exclusive: !r.MaxExclusive}
+	}
+	gap := fullRange
+	gap.setLower(cursor)
+	return append(out, gap)
+}
+
+// canonicalRange removes ignored versions from unbounded ends and
+// turns an inclusive <max> upper bound into an unbounded end,
+// as no version can be greater than x.x.x.
+// Build metadata is dropped, as it does not affect precedence.
+func canonicalRange(r Range) Range {
+	r.Min.BuildMetadata, r.Max.BuildMetadata = nil, nil
+	if r.upper().unbounded {
+		r.Max, r.MaxExclusive, r.MaxUnbounded = Version{}, false, true
+	}
+	if r.MinUnbounded {
+		r.Min, r.MinExclusive = Version{}, false
+	}
+	return r
+}
+
+// lowest version 0.0.0-0.
+var minVersion = Version{PreRelease: PreReleaseIdentifierList{PreReleaseIdentifier{}}}
+
+// successor returns the lowest version greater than a release,
+// e.g. 1.2.4-0 for 1.2.3 and 1.3.0-0 for 1.2.x.
+// Pre-releases are left as is, their successor appends an identifier, e.g. 1.2.3-rc.1.0.
+func successor(v Version) (Version, bool) {
+	if len(v.PreRelease) > 0 {
+		return v, false
+	}
+	if next, ok := nextRelease(v); ok {
+		return next, true
+	}
+	if v.Patch >= maxUint64-1 {
+		// the next patch would be read as x.
+		return v, false
+	}
+	pre := PreReleaseIdentifierList{PreReleaseIdentifier{}}
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1, PreRelease: pre}, true
+}
+
+// nextRelease returns the lowest version after a version ending in x,
+// e.g. 1.3.0-0 for 1.2.x.
+func nextRelease(v Version) (Version, bool) {
+	if v.Patch != maxUint64 || len(v.PreRelease) > 0 {
+		return v, false
+	}
+	pre := PreReleaseIdentifierList{PreReleaseIdentifier{}}
+	switch {
+	case v.Minor != maxUint64:
+		return Version{Major: v.Major, Minor: v.Minor + 1, PreRelease: pre}, true
+	case v.Major != maxUint64:
+		return Version{Major: v.Major + 1, PreRelease: pre}, true
+	}
+	return v, false
+}
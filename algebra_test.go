@@ -0,0 +1,109 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntersect(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     string
+		expected string
+	}{
+		{a: "1.0.0 - 2.0.0", b: "1.5.0 - 3.0.0", expected: "1.5.0 - 2.0.0"},
+		{a: "1.0.0 - 2.0.0", b: "2.0.0 - 3.0.0", expected: "=2.0.0"},
+		{a: "^1.2", b: "~1.4", expected: "1.4.0 - 1.4.x"},
+		{a: ">=1 && !=1.2.3", b: "<2", expected: ">=1.0.0 <1.2.3 || >1.2.3 <2.0.0"},
+		{a: "1 - 2 || 4 - 5", b: "1.5 - 4.5", expected: "1.5.0 - 2.0.0 || 4.0.0 - 4.5.0"},
+		{a: "1.0.0 - 2.0.0", b: "3.0.0 - 4.0.0", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.a+" && "+test.b, func(t *testing.T) {
+			t.Parallel()
+			a := MustNewConstraint(test.a)
+			b := MustNewConstraint(test.b)
+			assert.Equal(t, test.expected, Intersect(a, b).String())
+			assert.Equal(t, test.expected, Intersect(b, a).String())
+		})
+	}
+}
+
+func TestIntersect_empty(t *testing.T) {
+	t.Parallel()
+	c := Intersect(MustNewConstraint("1 - 2"), MustNewConstraint("3 - 4"))
+
+	assert.True(t, c.IsEmpty())
+	assert.False(t, c.Check(MustNewVersion("1.0.0")))
+	assert.False(t, c.Check(MustNewVersion("3.0.0")))
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     string
+		expected string
+	}{
+		{a: "1.0.0 - 2.0.0", b: "1.5.0 - 3.0.0", expected: "1.0.0 - 3.0.0"},
+		{a: "1.0.0 - 2.0.0", b: "3.0.0 - 4.0.0", expected: "1.0.0 - 2.0.0 || 3.0.0 - 4.0.0"},
+		{a: "~1.2", b: "1.2.5 - 1.3.x", expected: "1.2.0 - 1.3.x"},
+		{a: "~1.2", b: "~1.3", expected: "1.2.0 - 1.2.x || 1.3.0 - 1.3.x"},
+		{a: "<1.2.0", b: ">=1.2.0", expected: "*"},
+		{a: "<=1.2.3", b: ">=1.2.4-0", expected: "*"},
+		{a: "1.0.0 - 1.2.x", b: ">=1.3.0-0 <2.0.0", expected: ">=1.0.0 <2.0.0"},
+		{a: "!=1.2.3", b: "1 - 2", expected: "*"},
+		{a: "!=1.2.3", b: "2 - 3", expected: "<1.2.3 || >1.2.3"},
+	}
+	for _, test := range tests {
+		t.Run(test.a+" || "+test.b, func(t *testing.T) {
+			t.Parallel()
+			a := MustNewConstraint(test.a)
+			b := MustNewConstraint(test.b)
+			assert.Equal(t, test.expected, Union(a, b).String())
+			assert.Equal(t, test.expected, Union(b, a).String())
+		})
+	}
+}
+
+func TestComplement(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a        string
+		expected string
+	}{
+		{a: "1.2.0 - 1.4.0", expected: "<1.2.0 || >1.4.0"},
+		{a: "1.2.0 - 1.4.x", expected: "<1.2.0 || >1.4.x"},
+		{a: ">=1.2.3", expected: "<1.2.3"},
+		{a: "<1.2.3", expected: ">=1.2.3"},
+		{a: "!=1.2.3", expected: "=1.2.3"},
+		{a: "=1.2.3", expected: "<1.2.3 || >1.2.3"},
+		{a: "1 - 2 || 4 - 5", expected: "<1.0.0 || >2.0.0 <4.0.0 || >5.0.0"},
+		{a: "x - x", expected: "<0.0.0"},
+		{a: "<1 || >=1", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.a, func(t *testing.T) {
+			t.Parallel()
+			a := MustNewConstraint(test.a)
+			c := Complement(a)
+			assert.Equal(t, test.expected, c.String())
+
+			for _, v := range []string{
+				"0.0.0-rc.1", "0.0.0", "1.0.0", "1.2.3-rc.1", "1.2.3", "1.4.9", "3.0.0", "5.0.0", "5.0.1-0", "6.0.0",
+			} {
+				version := MustNewVersion(v)
+				assert.NotEqual(t, a.Check(version), c.Check(version), v)
+			}
+		})
+	}
+}
+
+func TestComplement_roundtrip(t *testing.T) {
+	t.Parallel()
+	a := MustNewConstraint("^1.2 || 3.1 - 3.4 && !=3.2.0")
+	c := Complement(Complement(a))
+	assert.Equal(t, "1.2.0 - 1.x.x || >=3.1.0 <3.2.0 || >3.2.0 <=3.4.0", c.String())
+	assert.Equal(t, "*", Union(a, Complement(a)).String())
+	assert.Empty(t, Intersect(a, Complement(a)).String())
+}
@@ -0,0 +1,571 @@
+package semver
+
+import (
+	"strconv"
+	"strings"
+
+	"pkg.package-operator.run/semver/internal"
+	"pkg.package-operator.run/semver/internal/ranges"
+)
+
+// Operator of a constraint Clause.
+type Operator int
+
+// Operators of constraint clauses.
+const (
+	OpEqual        Operator = iota + 1 // =
+	OpNotEqual                         // !=
+	OpGreater                          // >
+	OpGreaterEqual                     // >=
+	OpLess                             // <
+	OpLessEqual                        // <=
+	OpTilde                            // ~
+	OpCaret                            // ^
+	OpHyphen                           // 1.2 - 1.4
+)
+
+var operators = [...]string{
+	OpEqual:        "=",
+	OpNotEqual:     "!=",
+	OpGreater:      ">",
+	OpGreaterEqual: ">=",
+	OpLess:         "<",
+	OpLessEqual:    "<=",
+	OpTilde:        "~",
+	OpCaret:        "^",
+	OpHyphen:       "-",
+}
+
+// String returns the operator as written in constraints, e.g. >=.
+func (op Operator) String() string {
+	if op > 0 && int(op) < len(operators) {
+		return operators[op]
+	}
+	return "op(" + strconv.Itoa(int(op)) + ")"
+}
+
+var tokenOperators = map[ranges.Token]Operator{
+	ranges.EQUAL:         OpEqual,
+	ranges.NOT_EQUAL:     OpNotEqual,
+	ranges.GREATER:       OpGreater,
+	ranges.GREATER_EQUAL: OpGreaterEqual,
+	ranges.LESS:          OpLess,
+	ranges.LESS_EQUAL:    OpLessEqual,
+	ranges.TILDE:         OpTilde,
+	ranges.CARET:         OpCaret,
+}
+
+// AST is the syntax tree of a constraint, as returned by ParseAST.
+// It keeps the operators and wildcards as written,
+// while a Constraint only holds the resulting ranges.
+type AST struct {
+	// Input the AST was parsed from.
+	Input string
+	// Or lists the groups combined by ||.
+	Or []AndGroup
+}
+
+// String returns the constraint in a normalized form,
+// e.g. ">=1.2.x && <2 || ^3".
+func (a *AST) String() string {
+	groups := make([]string, len(a.Or))
+	for i := range a.Or {
+		groups[i] = a.Or[i].String()
+	}
+	return strings.Join(groups, " || ")
+}
+
+// Constraint lowers the AST into a Constraint,
+// validating that the clauses of each AndGroup can be satisfied.
+func (a *AST) Constraint(opts ...ConstraintOption) (Constraint, error) {
+	var options ConstraintOptions
+	for _, opt := range opts {
+		opt.ApplyToConstraintOptions(&options)
+	}
+	return a.constraint(options, nil)
+}
+
+// AndGroup is a list of clauses combined by && or spaces.
+type AndGroup struct {
+	// Span of the group within the input.
+	Span Span
+	// Clauses of the group.
+	Clauses []Clause
+}
+
+// String returns the clauses joined by &&.
+func (g *AndGroup) String() string {
+	clauses := make([]string, len(g.Clauses))
+	for i := range g.Clauses {
+		clauses[i] = g.Clauses[i].String()
+	}
+	return strings.Join(clauses, " && ")
+}
+
+// Clause is a single operator and version, e.g. ~1.2 or 1.2 - 1.4.
+type Clause struct {
+	// Span of the clause within the input, including the operator.
+	Span Span
+	// Op is the operator of the clause.
+	Op Operator
+	// Version operand, or the lower bound of hyphen ranges.
+	Version PartialVersion
+	// Upper bound of hyphen ranges, empty for other operators.
+	Upper PartialVersion
+
+	// position of the token that closed the clause,
+	// semantic errors are reported at this column.
+	closePos internal.Position
+}
+
+// String returns the clause, e.g. >=1.2.x or 1.2 - 1.4.
+func (c *Clause) String() string {
+	if c.Op == OpHyphen {
+		return c.Version.String() + " - " + c.Upper.String()
+	}
+	return c.Op.String() + c.Version.String()
+}
+
+// PartialVersion is a version as written in a constraint,
+// segments may be missing or wildcards, e.g. 1.x.
+type PartialVersion struct {
+	// Span of the version within the input.
+	Span Span
+	// Segments in order major, minor, patch.
+	Segments []VersionSegment
+	// PreRelease identifiers, only allowed after a full major.minor.patch.
+	PreRelease PreReleaseIdentifierList
+	// BuildMetadata identifiers, only allowed after a full major.minor.patch.
+	BuildMetadata []string
+}
+
+// String returns the version with wildcards written as x.
+func (v *PartialVersion) String() string {
+	segments := make([]string, len(v.Segments))
+	for i, s := range v.Segments {
+		segments[i] = s.String()
+	}
+	out := strings.Join(segments, ".")
+	if len(v.PreRelease) > 0 {
+		out += "-" + v.PreRelease.String()
+	}
+	if len(v.BuildMetadata) > 0 {
+		out += "+" + strings.Join(v.BuildMetadata, ".")
+	}
+	return out
+}
+
+// HasWildcard returns true if any segment is a wildcard.
+func (v *PartialVersion) HasWildcard() bool {
+	for _, s := range v.Segments {
+		if s.Wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// lower converts the partial version into a Version,
+// filling wildcards with the given number.
+// The returned index of the last segment is
+// 0=Major, 1=Minor or 2=Patch, wildcards count towards the previous segment.
+func (v *PartialVersion) lower(wildcard uint64) (out Version, last int) {
+	for i, s := range v.Segments {
+		if i > 0 {
+			last++
+		}
+		num := s.Number
+		if s.Wildcard {
+			num = wildcard
+		}
+		switch last {
+		case 0:
+			out.Major = num
+		case 1:
+			out.Minor = num
+		case 2:
+			out.Patch = num
+		}
+		if s.Wildcard && last != 0 {
+			last--
+		}
+	}
+	out.PreRelease = v.PreRelease
+	out.BuildMetadata = v.BuildMetadata
+	return out, last
+}
+
+// VersionSegment is a single number or wildcard of a PartialVersion.
+type VersionSegment struct {
+	// Span of the segment within the input.
+	Span Span
+	// Number of the segment, 0 for wildcards.
+	Number uint64
+	// Wildcard is true for x, X and *.
+	Wildcard bool
+}
+
+// String returns the number or x for wildcards.
+func (s VersionSegment) String() string {
+	if s.Wildcard {
+		return "x"
+	}
+	return strconv.FormatUint(s.Number, 10)
+}
+
+// ParseAST parses the given string into the syntax tree of a constraint.
+// Only syntax errors are reported, use AST.Constraint to validate the ranges.
+//
+// On error the AST contains all clauses parsed before the error.
+// With RecoverErrors broken clauses are dropped and parsing continues at the next || or &&,
+// returning all errors as ParseErrorList.
+func ParseAST(data string, opts ...ConstraintOption) (*AST, error) {
+	var options ConstraintOptions
+	for _, opt := range opts {
+		opt.ApplyToConstraintOptions(&options)
+	}
+	var p astParser
+	p.init([]byte(data))
+	p.recoverErrors = options.RecoverErrors
+	return p.parse()
+}
+
+type astParser struct {
+	scanner ranges.Scanner
+	src     []byte
+	errors  []*ParseError // scanner errors
+
+	ast             *AST
+	group           AndGroup        // active group
+	clause          *Clause         // active clause
+	version         *PartialVersion // active version being parsed
+	expectingNumber bool            // if we expect a number next
+	dots            int             // dots within the active version
+
+	recoverErrors bool           // continue parsing after errors
+	skipping      bool           // skipping a broken clause
+	diagnostics   ParseErrorList // errors collected when recovering
+}
+
+func (p *astParser) init(src []byte) {
+	p.src = src
+	p.ast = &AST{Input: string(src)}
+	p.scanner.Init(src, func(pos internal.Position, kind ranges.ErrorKind, msg string) {
+		if kind == ranges.NumberOverflow {
+			p.errors = append(p.errors, parseErrorf(pos, ErrOverflow, "%s", msg))
+			return
+		}
+		p.errors = append(p.errors, parseErrorf(pos, ErrInvalidCharacter, "%s", msg))
+	})
+}
+
+func (p *astParser) parse() (*AST, error) {
+	for {
+		pos, tok, lit := p.scanner.Scan()
+
+		var (
+			done bool
+			err  error
+		)
+		switch {
+		case p.skipping:
+			// skip the broken clause until the next boundary,
+			// including further scanner errors within it.
+			done = p.resume(tok)
+
+		case len(p.errors) > 0:
+			err = p.errors[0]
+
+		default:
+			done, err = p.step(pos, tok, lit)
+		}
+		p.errors = nil
+
+		if err != nil {
+			if !p.recoverErrors {
+				// keep the clauses parsed so far.
+				p.closeGroup()
+				return p.ast, withInput(err, p.src)
+			}
+			p.addDiagnostic(err)
+			p.skipping = true
+			// the boundary may already be consumed.
+			done = p.resume(tok)
+		}
+		if done {
+			break
+		}
+	}
+	if len(p.diagnostics) > 0 {
+		return p.ast, p.diagnostics
+	}
+	return p.ast, nil
+}
+
+// step processes a single token.
+// done is true after EOF was processed.
+func (p *astParser) step(pos internal.Position, tok ranges.Token, lit uint64) (done bool, err error) {
+	start, end := p.scanner.Span()
+	switch tok {
+	case ranges.ILLEGAL:
+		// already reported by the scanner.
+
+	case ranges.SPACE:
+		return false, p.closeVersion(pos)
+
+	case ranges.EQUAL, ranges.NOT_EQUAL,
+		ranges.GREATER, ranges.GREATER_EQUAL,
+		ranges.LESS, ranges.LESS_EQUAL,
+		ranges.TILDE, ranges.CARET:
+		if err := p.closeClause(pos); err != nil {
+			return false, err
+		}
+		p.clause = &Clause{
+			Span: Span{Start: start, End: end},
+			Op:   tokenOperators[tok],
+		}
+
+	case ranges.AND:
+		if p.clause == nil {
+			return false, parseErrorf(pos, ErrSyntax, "AND empty range constraint")
+		}
+		return false, p.closeClause(pos)
+
+	case ranges.OR:
+		if p.clause == nil {
+			return false, parseErrorf(pos, ErrSyntax, "OR empty range constraint")
+		}
+		if err := p.closeClause(pos); err != nil {
+			return false, err
+		}
+		p.closeGroup()
+
+	case ranges.HYPHEN:
+		switch {
+		case p.clause == nil:
+			return false, parseErrorf(pos, ErrMissingSegment, "hyphen range without lower bound")
+		case p.clause.Op == OpHyphen:
+			// we are already within a HYPON range.
+			// seeing a HYPON again is an error.
+			return false, parseErrorf(pos, ErrSyntax, "double hyphen in range constraint")
+		case p.clause.Op != 0:
+			return false, parseErrorf(pos, ErrSyntax, "hyphen range after operator %s", p.clause.Op)
+		}
+		if err := p.closeVersion(pos); err != nil {
+			return false, err
+		}
+		p.clause.Op = OpHyphen
+
+	case ranges.EOF:
+		if err := p.closeClause(pos); err != nil {
+			return false, err
+		}
+		p.closeGroup()
+		return true, nil
+
+	case ranges.NUMBER, ranges.WILDCARD:
+		return false, p.addSegment(pos, VersionSegment{
+			Span:     Span{Start: start, End: end},
+			Number:   lit,
+			Wildcard: tok == ranges.WILDCARD,
+		})
+
+	case ranges.PRERELEASE:
+		if err := p.requireFullVersion(pos, "pre-release"); err != nil {
+			return false, err
+		}
+		prParts, err := parsePreReleaseIdentifiers(pos, p.scanner.Text())
+		if err != nil {
+			return false, err
+		}
+		p.version.PreRelease = prParts
+		p.version.Span.End = end
+
+	case ranges.BUILD:
+		if err := p.requireFullVersion(pos, "build metadata"); err != nil {
+			return false, err
+		}
+		parts, err := parseBuildIdentifiers(pos, p.scanner.Text())
+		if err != nil {
+			return false, err
+		}
+		p.version.BuildMetadata = parts
+		p.version.Span.End = end
+
+	case ranges.DOT:
+		if p.version == nil || p.expectingNumber {
+			return false, parseErrorf(pos, ErrSyntax, "unexpected dot")
+		}
+		p.dots++
+		if p.dots > 2 {
+			return false, parseErrorf(pos, ErrSyntax, "found 3rd dot when parsing semver")
+		}
+		p.expectingNumber = true
+		p.version.Span.End = end
+	}
+	return false, nil
+}
+
+// requireFullVersion checks that the active version has concrete major, minor and patch numbers,
+// before a pre-release or build metadata suffix is added.
+func (p *astParser) requireFullVersion(pos internal.Position, suffix string) error {
+	switch {
+	case p.version == nil:
+		return parseErrorf(pos, ErrSyntax, "%s without version", suffix)
+	case len(p.version.Segments) < 3:
+		return parseErrorf(pos, ErrMissingSegment, "%s requires major.minor.patch, got %s", suffix, p.version.String())
+	}
+	for _, s := range p.version.Segments {
+		if s.Wildcard {
+			return parseErrorf(pos, ErrSyntax, "%s not allowed on wildcard version %s", suffix, p.version.String())
+		}
+	}
+	return nil
+}
+
+// addSegment adds a number or wildcard to the active version,
+// starting a new version or clause if needed.
+func (p *astParser) addSegment(pos internal.Position, seg VersionSegment) error {
+	if p.clause == nil {
+		// bare versions start a hyphen range.
+		p.clause = &Clause{Span: seg.Span}
+	}
+
+	switch {
+	case p.version != nil && !p.expectingNumber:
+		return parseErrorf(pos, ErrSyntax, "missing dot between version segments")
+
+	case p.version != nil:
+
+	case len(p.clause.Version.Segments) == 0:
+		p.version = &p.clause.Version
+
+	case p.clause.Op == OpHyphen && len(p.clause.Upper.Segments) == 0:
+		p.version = &p.clause.Upper
+
+	default:
+		return parseErrorf(pos, ErrSyntax, "missing operator before version")
+	}
+
+	if len(p.version.Segments) == 0 {
+		p.version.Span.Start = seg.Span.Start
+		p.dots = 0
+	}
+	p.version.Segments = append(p.version.Segments, seg)
+	p.version.Span.End = seg.Span.End
+	p.expectingNumber = false
+	return nil
+}
+
+func (p *astParser) closeVersion(pos internal.Position) error {
+	if p.version == nil {
+		return nil
+	}
+	if p.expectingNumber {
+		// semver clause incomplete!
+		return parseErrorf(pos, ErrMissingSegment, "semver clause incomplete")
+	}
+	p.clause.Span.End = p.version.Span.End
+	p.version = nil // no active version
+	return nil
+}
+
+func (p *astParser) closeClause(pos internal.Position) error {
+	if p.clause == nil {
+		return nil
+	}
+	if err := p.closeVersion(pos); err != nil {
+		return err
+	}
+
+	c := p.clause
+	switch {
+	case c.Op == 0:
+		return parseErrorf(pos, ErrSyntax, "range closed without operator")
+	case len(c.Version.Segments) == 0:
+		return parseErrorf(pos, ErrMissingSegment, "missing version after %s", c.Op)
+	case c.Op == OpHyphen && len(c.Upper.Segments) == 0:
+		return parseErrorf(pos, ErrMissingSegment, "hyphen range without upper bound")
+	}
+	c.closePos = pos
+
+	if len(p.group.Clauses) == 0 {
+		p.group.Span.Start = c.Span.Start
+	}
+	p.group.Span.End = c.Span.End
+	p.group.Clauses = append(p.group.Clauses, *c)
+	p.clause = nil
+	return nil
+}
+
+// closeGroup moves the active group into the AST.
+func (p *astParser) closeGroup() {
+	if len(p.group.Clauses) > 0 {
+		p.ast.Or = append(p.ast.Or, p.group)
+	}
+	p.group = AndGroup{}
+}
+
+// resume drops the broken clause and continues parsing after the next boundary.
+func (p *astParser) resume(tok ranges.Token) (done bool) {
+	if !isClauseBoundary(tok) {
+		return false
+	}
+	p.skipping = false
+	p.clause = nil
+	p.version = nil
+	p.expectingNumber = false
+
+	switch tok {
+	case ranges.OR:
+		p.closeGroup()
+	case ranges.EOF:
+		p.closeGroup()
+		return true
+	}
+	return false
+}
+
+// addDiagnostic records an error in recovering mode.
+func (p *astParser) addDiagnostic(err error) {
+	p.diagnostics = appendDiagnostic(p.diagnostics, err, p.src)
+}
+
+// isClauseBoundary returns true for tokens that end a clause.
+func isClauseBoundary(tok ranges.Token) bool {
+	return tok == ranges.AND || tok == ranges.OR || tok == ranges.EOF
+}
+
+// parsePreReleaseIdentifiers parses the identifiers of a PRERELEASE token at pos.
+func parsePreReleaseIdentifiers(pos internal.Position, text string) (PreReleaseIdentifierList, error) {
+	var prParts PreReleaseIdentifierList
+	idPos := pos + 1
+	for _, s := range strings.Split(text, ".") {
+		if len(s) == 0 {
+			return nil, parseErrorf(idPos, ErrInvalidIdentifier, "pre release identifier empty")
+		}
+		if !isPreReleaseIdentifier(s) {
+			return nil, parseErrorf(idPos, ErrInvalidIdentifier, "invalid pre release identifier %q", s)
+		}
+		prParts = append(prParts, ToPreReleaseIdentifier(s))
+		idPos += internal.Position(len(s) + 1)
+	}
+	return prParts, nil
+}
+
+// parseBuildIdentifiers parses the identifiers of a BUILD token at pos.
+func parseBuildIdentifiers(pos internal.Position, text string) ([]string, error) {
+	var parts []string
+	idPos := pos + 1
+	for _, s := range strings.Split(text, ".") {
+		if len(s) == 0 {
+			return nil, parseErrorf(idPos, ErrInvalidIdentifier, "build identifier empty")
+		}
+		if !isBuildIdentifier(s) {
+			return nil, parseErrorf(idPos, ErrInvalidIdentifier, "invalid build identifier %q", s)
+		}
+		parts = append(parts, s)
+		idPos += internal.Position(len(s) + 1)
+	}
+	return parts, nil
+}
@@ -0,0 +1,193 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAST(t *testing.T) {
+	t.Parallel()
+	ast, err := ParseAST(`>=1.2.x <2 || 1.0.0-rc.1 - 1.4`)
+	require.NoError(t, err)
+
+	expected := &AST{
+		Input: `>=1.2.x <2 || 1.0.0-rc.1 - 1.4`,
+		Or: []AndGroup{
+			{
+				Span: Span{Start: 0, End: 10},
+				Clauses: []Clause{
+					{
+						Span: Span{Start: 0, End: 7},
+						Op:   OpGreaterEqual,
+						Version: PartialVersion{
+							Span: Span{Start: 2, End: 7},
+							Segments: []VersionSegment{
+								{Span: Span{Start: 2, End: 3}, Number: 1},
+								{Span: Span{Start: 4, End: 5}, Number: 2},
+								{Span: Span{Start: 6, End: 7}, Wildcard: true},
+							},
+						},
+						closePos: 9,
+					},
+					{
+						Span: Span{Start: 8, End: 10},
+						Op:   OpLess,
+						Version: PartialVersion{
+							Span: Span{Start: 9, End: 10},
+							Segments: []VersionSegment{
+								{Span: Span{Start: 9, End: 10}, Number: 2},
+							},
+						},
+						closePos: 12,
+					},
+				},
+			},
+			{
+				Span: Span{Start: 14, End: 30},
+				Clauses: []Clause{
+					{
+						Span: Span{Start: 14, End: 30},
+						Op:   OpHyphen,
+						Version: PartialVersion{
+							Span: Span{Start: 14, End: 24},
+							Segments: []VersionSegment{
+								{Span: Span{Start: 14, End: 15}, Number: 1},
+								{Span: Span{Start: 16, End: 17}},
+								{Span: Span{Start: 18, End: 19}},
+							},
+							PreRelease: PreReleaseIdentifierList{
+								ToPreReleaseIdentifier("rc"), ToPreReleaseIdentifier("1"),
+							},
+						},
+						Upper: PartialVersion{
+							Span: Span{Start: 27, End: 30},
+							Segments: []VersionSegment{
+								{Span: Span{Start: 27, End: 28}, Number: 1},
+								{Span: Span{Start: 29, End: 30}, Number: 4},
+							},
+						},
+						closePos: 30,
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, ast)
+	assert.Equal(t, "<2", ast.Input[ast.Or[0].Clauses[1].Span.Start:ast.Or[0].Clauses[1].Span.End])
+}
+
+func TestAST_String(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `>= 1.2.X,<2`, expected: `>=1.2.x && <2`},
+		{input: `~1 || ^2.*`, expected: `~1 || ^2.x`},
+		{input: `1 -2`, expected: `1 - 2`},
+		{input: `!=1.2.3-rc.1+b1`, expected: `!=1.2.3-rc.1+b1`},
+		{input: `=1 &&`, expected: `=1`},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			ast, err := ParseAST(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ast.String())
+		})
+	}
+}
+
+func TestParseAST_error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input       string
+		kind        error
+		expectedErr string
+	}{
+		{input: `- 2`, kind: ErrMissingSegment, expectedErr: "col 1: hyphen range without lower bound"},
+		{input: `1 -`, kind: ErrMissingSegment, expectedErr: "col 3: hyphen range without upper bound"},
+		{input: `>=`, kind: ErrMissingSegment, expectedErr: "col 2: missing version after >="},
+		{input: `>= <1`, kind: ErrMissingSegment, expectedErr: "col 4: missing version after >="},
+		{input: `>=1 - 2`, kind: ErrSyntax, expectedErr: "col 5: hyphen range after operator >="},
+		{input: `=1.2 1.3`, kind: ErrSyntax, expectedErr: "col 6: missing operator before version"},
+		{input: `=1x`, kind: ErrSyntax, expectedErr: "col 3: missing dot between version segments"},
+		{input: `=.1`, kind: ErrSyntax, expectedErr: "col 2: unexpected dot"},
+		{input: `=1..2`, kind: ErrSyntax, expectedErr: "col 4: unexpected dot"},
+		{input: `1.2.3`, kind: ErrSyntax, expectedErr: "col 5: range closed without operator"},
+		{input: `>=18446744073709551616`, kind: ErrOverflow, expectedErr: "col 3: number 18446744073709551616 too large"},
+		{input: `=1.99999999999999999999.0`, kind: ErrOverflow, expectedErr: "col 4: number 99999999999999999999 too large"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseAST(test.input)
+			require.EqualError(t, err, test.expectedErr)
+			require.ErrorIs(t, err, test.kind)
+
+			// NewConstraint reports the same errors.
+			_, err = NewConstraint(test.input)
+			require.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestParseAST_partial(t *testing.T) {
+	t.Parallel()
+	ast, err := ParseAST(`^1 && ~2.3 || =1.2.3.4 || <3`)
+	require.EqualError(t, err, "col 21: found 3rd dot when parsing semver")
+	assert.Equal(t, `^1 && ~2.3`, ast.String())
+}
+
+func TestParseAST_recoverErrors(t *testing.T) {
+	t.Parallel()
+	ast, err := ParseAST(`^1 && >=2.x.x.x || - 3 || <3`, RecoverErrors{})
+	require.EqualError(t, err, "col 14: found 3rd dot when parsing semver\ncol 20: hyphen range without lower bound")
+	assert.Equal(t, `^1 || <3`, ast.String())
+}
+
+func TestAST_Constraint(t *testing.T) {
+	t.Parallel()
+	inputs := []string{
+		`1.2.3 - 1.3.4 || 2.3.4 - 4.5.3`,
+		`=1.2.3 || >1.2.3 <5.4.0 && 1.2.4 - 2.3.4 || !=3.0.0`,
+		`~1.x || ^0.2`,
+		`>=1.2.0-rc.1 <2`,
+	}
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			ast, err := ParseAST(input)
+			require.NoError(t, err)
+			c, err := ast.Constraint()
+			require.NoError(t, err)
+
+			expected := MustNewConstraint(input)
+			assert.Equal(t, unwrapConstraint(expected), unwrapConstraint(c))
+			assert.Equal(t, input, c.String())
+		})
+	}
+
+	t.Run("over-constrained", func(t *testing.T) {
+		t.Parallel()
+		ast, err := ParseAST(`>=2 <1`)
+		require.NoError(t, err)
+		_, err = ast.Constraint()
+		require.EqualError(t, err, "col 6: over-constrained, ranges do not overlap: >=2.0.0 AND <1.0.0")
+		require.ErrorIs(t, err, ErrOverConstrained)
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		t.Parallel()
+		ast, err := ParseAST(`~1.2`)
+		require.NoError(t, err)
+		ast.Input = ""
+		ast.Or[0].Clauses[0].Op = OpCaret
+		c, err := ast.Constraint()
+		require.NoError(t, err)
+		assert.Equal(t, "^1.2", c.String())
+		assert.True(t, c.Check(MustNewVersion("1.3.0")))
+	})
+}
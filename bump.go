@@ -0,0 +1,125 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IncMajor returns the next major version.
+// Minor and Patch are reset to 0 and pre-release and build metadata are dropped.
+// A pre-release of a major version is promoted to its release:
+// 2.0.0-rc.1 => 2.0.0, while 1.2.3 => 2.0.0.
+func (v *Version) IncMajor() (Version, error) {
+	if len(v.PreRelease) > 0 && v.Minor == 0 && v.Patch == 0 {
+		return Version{Major: v.Major}, nil
+	}
+	if v.Major == maxUint64 {
+		return Version{}, fmt.Errorf("incrementing major of %s: %w", v.String(), ErrOverflow)
+	}
+	return Version{Major: v.Major + 1}, nil
+}
+
+// IncMinor returns the next minor version.
+// Patch is reset to 0 and pre-release and build metadata are dropped.
+// A pre-release of a minor version is promoted to its release:
+// 1.3.0-rc.1 => 1.3.0, while 1.2.3 => 1.3.0.
+func (v *Version) IncMinor() (Version, error) {
+	if len(v.PreRelease) > 0 && v.Patch == 0 {
+		return Version{Major: v.Major, Minor: v.Minor}, nil
+	}
+	if v.Minor == maxUint64 {
+		return Version{}, fmt.Errorf("incrementing minor of %s: %w", v.String(), ErrOverflow)
+	}
+	return Version{Major: v.Major, Minor: v.Minor + 1}, nil
+}
+
+// IncPatch returns the next patch version.
+// Pre-release and build metadata are dropped.
+// A pre-release is promoted to its release:
+// 1.2.3-rc.1 => 1.2.3, while 1.2.3 => 1.2.4.
+func (v *Version) IncPatch() (Version, error) {
+	if len(v.PreRelease) > 0 {
+		return v.Release(), nil
+	}
+	if v.Patch == maxUint64 {
+		return Version{}, fmt.Errorf("incrementing patch of %s: %w", v.String(), ErrOverflow)
+	}
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}, nil
+}
+
+// Release returns the release version of a pre-release, by dropping
+// pre-release and build metadata: 1.2.3-rc.1+meta => 1.2.3.
+func (v *Version) Release() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// IncPreRelease returns the next pre-release version.
+// The optional id is a dot separated list of identifiers prefixing the pre-release,
+// e.g. "rc" or "beta". Build metadata is always dropped.
+//
+//   - 1.2.3 with id "alpha" => 1.2.4-alpha.0
+//   - 1.2.3 without id => 1.2.4-0
+//   - 1.2.3-rc.1 with id "rc" or without id => 1.2.3-rc.2
+//   - 1.2.3-rc without id => 1.2.3-rc.0
+//   - 1.2.3-beta.2 with id "rc" => 1.2.3-rc.0
+//
+// An error is returned, if the id is invalid or the resulting version
+// would not be greater than the current version, e.g. 1.2.3-rc.1 with id "alpha".
+func (v *Version) IncPreRelease(id string) (Version, error) {
+	var ids PreReleaseIdentifierList
+	if len(id) > 0 {
+		for part := range strings.SplitSeq(id, ".") {
+			if len(part) == 0 || !isPreReleaseIdentifier(part) {
+				return Version{}, fmt.Errorf("invalid pre release identifier %q", part)
+			}
+			ids = append(ids, ToPreReleaseIdentifier(part))
+		}
+	}
+
+	if len(v.PreRelease) == 0 {
+		next, err := v.IncPatch()
+		if err != nil {
+			return Version{}, err
+		}
+		next.PreRelease = append(ids, PreReleaseIdentifier{})
+		return next, nil
+	}
+
+	next := v.Release()
+	if !hasPreReleasePrefix(v.PreRelease, ids) {
+		next.PreRelease = append(ids, PreReleaseIdentifier{})
+		if !next.GreaterThan(*v) {
+			return Version{}, fmt.Errorf(
+				"pre release %s would not be greater than %s", next.String(), v.String())
+		}
+		return next, nil
+	}
+
+	next.PreRelease = make(PreReleaseIdentifierList, len(v.PreRelease))
+	copy(next.PreRelease, v.PreRelease)
+	last := &next.PreRelease[len(next.PreRelease)-1]
+	num, isNum := last.GetNumber()
+	switch {
+	case !isNum || len(next.PreRelease) == len(ids):
+		// nothing to increment, start counting.
+		next.PreRelease = append(next.PreRelease, PreReleaseIdentifier{})
+	case num == maxUint64:
+		return Version{}, fmt.Errorf("incrementing pre release of %s: %w", v.String(), ErrOverflow)
+	default:
+		last.num = num + 1
+	}
+	return next, nil
+}
+
+// checks whether the pre release list starts with the given prefix identifiers.
+func hasPreReleasePrefix(l, prefix PreReleaseIdentifierList) bool {
+	if len(prefix) > len(l) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i].Compare(l[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_Inc(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version string
+		major   string
+		minor   string
+		patch   string
+		release string
+	}{
+		{
+			version: "1.2.3",
+			major:   "2.0.0", minor: "1.3.0", patch: "1.2.4", release: "1.2.3",
+		},
+		{
+			version: "1.2.3+meta",
+			major:   "2.0.0", minor: "1.3.0", patch: "1.2.4", release: "1.2.3",
+		},
+		{
+			version: "1.2.3-rc.1",
+			major:   "2.0.0", minor: "1.3.0", patch: "1.2.3", release: "1.2.3",
+		},
+		{
+			version: "1.3.0-rc.1",
+			major:   "2.0.0", minor: "1.3.0", patch: "1.3.0", release: "1.3.0",
+		},
+		{
+			version: "2.0.0-rc.1+meta",
+			major:   "2.0.0", minor: "2.0.0", patch: "2.0.0", release: "2.0.0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			t.Parallel()
+			v := MustNewVersion(test.version)
+
+			major, err := v.IncMajor()
+			require.NoError(t, err)
+			assert.Equal(t, test.major, major.String())
+
+			minor, err := v.IncMinor()
+			require.NoError(t, err)
+			assert.Equal(t, test.minor, minor.String())
+
+			patch, err := v.IncPatch()
+			require.NoError(t, err)
+			assert.Equal(t, test.patch, patch.String())
+
+			release := v.Release()
+			assert.Equal(t, test.release, release.String())
+		})
+	}
+}
+
+func TestVersion_Inc_overflow(t *testing.T) {
+	t.Parallel()
+	v := Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64}
+
+	_, err := v.IncMajor()
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = v.IncMinor()
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = v.IncPatch()
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = v.IncPreRelease("")
+	require.ErrorIs(t, err, ErrOverflow)
+
+	v = Version{
+		Major: 1, Minor: 2, Patch: 3,
+		PreRelease: PreReleaseIdentifierList{toPR("rc"), {num: maxUint64}},
+	}
+	_, err = v.IncPreRelease("rc")
+	require.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestVersion_IncPreRelease(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version  string
+		id       string
+		expected string
+	}{
+		{version: "1.2.3", id: "alpha", expected: "1.2.4-alpha.0"},
+		{version: "1.2.3", id: "", expected: "1.2.4-0"},
+		{version: "1.2.3+meta", id: "rc", expected: "1.2.4-rc.0"},
+		{version: "1.2.3-rc.1", id: "rc", expected: "1.2.3-rc.2"},
+		{version: "1.2.3-rc.1", id: "", expected: "1.2.3-rc.2"},
+		{version: "1.2.3-rc", id: "", expected: "1.2.3-rc.0"},
+		{version: "1.2.3-rc", id: "rc", expected: "1.2.3-rc.0"},
+		{version: "1.2.3-1", id: "", expected: "1.2.3-2"},
+		{version: "1.2.3-beta.2", id: "rc", expected: "1.2.3-rc.0"},
+		{version: "1.2.3-beta.2+meta", id: "beta", expected: "1.2.3-beta.3"},
+		{version: "1.2.3-beta.next.2", id: "beta.next", expected: "1.2.3-beta.next.3"},
+	}
+	for _, test := range tests {
+		t.Run(test.version+" "+test.id, func(t *testing.T) {
+			t.Parallel()
+			v := MustNewVersion(test.version)
+			next, err := v.IncPreRelease(test.id)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, next.String())
+			assert.True(t, next.GreaterThan(v))
+		})
+	}
+}
+
+func TestVersion_IncPreRelease_error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version     string
+		id          string
+		expectedErr string
+	}{
+		{
+			version:     "1.2.3-rc.1",
+			id:          "alpha",
+			expectedErr: "pre release 1.2.3-alpha.0 would not be greater than 1.2.3-rc.1",
+		},
+		{
+			version:     "1.2.3",
+			id:          "r_c",
+			expectedErr: `invalid pre release identifier "r_c"`,
+		},
+		{
+			version:     "1.2.3",
+			id:          "rc..1",
+			expectedErr: `invalid pre release identifier ""`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.version+" "+test.id, func(t *testing.T) {
+			t.Parallel()
+			v := MustNewVersion(test.version)
+			_, err := v.IncPreRelease(test.id)
+			require.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
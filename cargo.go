@@ -0,0 +1,78 @@
+package semver
+
+import (
+	"strings"
+)
+
+var cargoOperators = []string{">=", "<=", ">", "<", "=", "~", "^"}
+
+// ParseCargo parses a Cargo version requirement into a Constraint,
+// e.g. "1.2.3", ">=1.2, <1.5" or "~0.3".
+//
+// Differences to NewConstraint:
+//   - a bare version means caret, e.g. 1.2.3 := ^1.2.3, unless it has a wildcard, e.g. 1.2.* := =1.2.*
+//   - comparators are separated by comma, there is no logical OR
+//   - ^0.0.3 only matches 0.0.3, ^0.2.3 := >=0.2.3, <0.3.0
+//   - pre-releases only match, if a comparator has a pre-release with the same major.minor.patch
+//
+// The returned Constraint keeps the input as its String representation.
+func ParseCargo(data string) (Constraint, error) {
+	if len(strings.TrimSpace(data)) == 0 {
+		return nil, errorAtOffset(data, 0, ErrEmptyInput, "empty")
+	}
+
+	r := fullRange
+	var preReleases []Version
+	offset := 0
+	for comparator := range strings.SplitSeq(data, ",") {
+		tok := trimToken(dialectToken{text: comparator, offset: offset})
+		offset += len(comparator) + len(",")
+		if len(tok.text) == 0 {
+			return nil, errorAtOffset(data, tok.offset, ErrSyntax, "empty comparator")
+		}
+
+		op, rest := cutOperator(tok.text, cargoOperators)
+		vtok := trimToken(dialectToken{text: rest, offset: tok.offset + len(op)})
+		p, err := cargoDialect.parseLooseVersion(data, vtok)
+		if err != nil {
+			return nil, err
+		}
+		if len(op) == 0 && p.wildcard {
+			// 1.2.* := =1.2.*
+			op = "="
+		}
+		cr, err := cargoDialect.comparatorRange(data, op, p, vtok)
+		if err != nil {
+			return nil, err
+		}
+		r.narrow(cr)
+		preReleases = append(preReleases, p.preReleases()...)
+	}
+
+	return &originalInputConstraint{
+		Constraint: &preReleaseConstraint{
+			Constraint:  &r,
+			preReleases: preReleases,
+		},
+		original: data,
+	}, nil
+}
+
+// trimToken removes surrounding whitespace from the token.
+func trimToken(tok dialectToken) dialectToken {
+	text := strings.TrimLeft(tok.text, " \t")
+	tok.offset += len(tok.text) - len(text)
+	tok.text = strings.TrimRight(text, " \t")
+	return tok
+}
+
+// FormatCargo prints the Constraint as Cargo version requirement,
+// using the caret and tilde shorthands where they match exactly.
+// Constraints that allow multiple disjoint ranges, e.g. logical ORs or !=,
+// can not be expressed as Cargo requirement and return ErrNotExpressible.
+//
+// Note that Cargo only matches pre-releases,
+// if a comparator has a pre-release with the same major.minor.patch.
+func FormatCargo(c Constraint) (string, error) {
+	return Format(c, StyleCargo)
+}
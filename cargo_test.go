@@ -0,0 +1,285 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from the Rust semver crate's version_req tests.
+func TestParseCargo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		matches  []string
+		rejected []string
+	}{
+		{
+			input:    "1.0.0",
+			matches:  []string{"1.0.0", "1.0.1"},
+			rejected: []string{"0.9.9", "0.10.0", "0.1.0", "1.0.0-pre", "0.0.1"},
+		},
+		{
+			input:    ">= 1.0.0",
+			matches:  []string{"1.0.0", "2.0.0"},
+			rejected: []string{"0.1.0", "0.0.1", "1.0.0-pre", "2.0.0-alpha"},
+		},
+		{
+			input:    ">= 2.1.0-alpha2",
+			matches:  []string{"2.1.0-alpha2", "2.1.0-alpha3", "2.1.0", "3.0.0"},
+			rejected: []string{"2.0.0", "2.1.0-alpha1", "2.0.0-alpha2", "3.0.0-alpha2"},
+		},
+		{
+			input:    "< 1.0.0",
+			matches:  []string{"0.1.0", "0.0.1"},
+			rejected: []string{"1.0.0", "1.0.0-beta", "1.0.1", "0.9.9-alpha"},
+		},
+		{
+			input:    "<= 2.1.0-alpha2",
+			matches:  []string{"2.1.0-alpha2", "2.1.0-alpha1", "2.0.0", "1.0.0"},
+			rejected: []string{"2.1.0", "2.2.0-alpha1", "2.0.0-alpha2", "1.0.0-alpha2"},
+		},
+		{
+			input:   ">1.0.0-alpha, <1.0.0",
+			matches: []string{"1.0.0-beta"},
+		},
+		{
+			input:   ">1.0.0-alpha, <1.0",
+			matches: []string{"1.0.0-beta"},
+		},
+		{
+			input:   ">1.0.0-alpha, <1",
+			matches: []string{"1.0.0-beta"},
+		},
+		{
+			input:    ">=0.5.1-alpha3, <0.6",
+			matches:  []string{"0.5.1-alpha3", "0.5.1-alpha4", "0.5.1-beta", "0.5.1", "0.5.5"},
+			rejected: []string{"0.5.1-alpha1", "0.5.2-alpha3", "0.5.5-pre", "0.5.0-pre", "0.6.0", "0.6.0-pre"},
+		},
+		{
+			input:    "=0.1.0",
+			matches:  []string{"0.1.0"},
+			rejected: []string{"0.1.1", "0.0.1"},
+		},
+		{
+			input:   "=0.1.0+meta",
+			matches: []string{"0.1.0", "0.1.0+meta", "0.1.0+any"},
+		},
+		{
+			input:    "~1",
+			matches:  []string{"1.0.0", "1.0.1", "1.1.1"},
+			rejected: []string{"0.9.1", "2.9.0", "0.0.9"},
+		},
+		{
+			input:    "~1.2",
+			matches:  []string{"1.2.0", "1.2.1"},
+			rejected: []string{"1.1.1", "1.3.0", "0.0.9"},
+		},
+		{
+			input:    "~1.2.2",
+			matches:  []string{"1.2.2", "1.2.4"},
+			rejected: []string{"1.2.1", "1.9.0", "1.0.9", "2.0.1", "0.1.3"},
+		},
+		{
+			input:    "~1.2.3-beta.2",
+			matches:  []string{"1.2.3", "1.2.4", "1.2.3-beta.2", "1.2.3-beta.4"},
+			rejected: []string{"1.3.3", "1.1.4", "1.2.3-beta.1", "1.2.4-beta.2"},
+		},
+		{
+			input:    "^1",
+			matches:  []string{"1.1.2", "1.1.0", "1.2.1", "1.0.1"},
+			rejected: []string{"0.9.1", "2.9.0", "0.1.4", "1.0.0-beta1", "0.1.0-alpha", "1.0.1-pre"},
+		},
+		{
+			input:    "^1.1",
+			matches:  []string{"1.1.2", "1.1.0", "1.2.1"},
+			rejected: []string{"0.9.1", "2.9.0", "1.0.1", "0.1.4"},
+		},
+		{
+			input:    "^1.1.2",
+			matches:  []string{"1.1.2", "1.1.4", "1.2.1"},
+			rejected: []string{"0.9.1", "2.9.0", "1.1.1", "0.0.1", "1.1.2-alpha1", "1.1.3-alpha1", "2.9.0-alpha1"},
+		},
+		{
+			input:    "^0.1.2",
+			matches:  []string{"0.1.2", "0.1.4"},
+			rejected: []string{"0.9.1", "0.0.1", "0.1.1", "0.1.2-beta", "0.1.3-alpha", "0.2.0-pre"},
+		},
+		{
+			input:    "^0.5.1-alpha3",
+			matches:  []string{"0.5.1-alpha3", "0.5.1-alpha4", "0.5.1-beta", "0.5.1", "0.5.5"},
+			rejected: []string{"0.5.1-alpha1", "0.5.2-alpha3", "0.5.5-pre", "0.5.0-pre", "0.6.0"},
+		},
+		{
+			input:    "^0.0.2",
+			matches:  []string{"0.0.2"},
+			rejected: []string{"0.9.1", "0.0.1", "0.1.4", "0.0.3"},
+		},
+		{
+			input:    "0.0.3",
+			matches:  []string{"0.0.3"},
+			rejected: []string{"0.0.4", "0.0.2"},
+		},
+		{
+			input:    "^0.0",
+			matches:  []string{"0.0.2", "0.0.0"},
+			rejected: []string{"0.9.1", "0.1.4"},
+		},
+		{
+			input:    "^0",
+			matches:  []string{"0.9.1", "0.0.2", "0.0.0"},
+			rejected: []string{"2.9.0", "1.1.1"},
+		},
+		{
+			input:    "^1.4.2-beta.5",
+			matches:  []string{"1.4.2", "1.4.3", "1.4.2-beta.5", "1.4.2-beta.6"},
+			rejected: []string{"0.9.9", "2.0.0", "1.4.2-alpha", "1.4.2-beta.4", "1.4.3-beta.5"},
+		},
+		{
+			input:    "*",
+			matches:  []string{"0.9.1", "2.9.0", "0.0.9", "1.0.1", "1.1.1"},
+			rejected: []string{"1.0.0-pre"},
+		},
+		{
+			input:    "1.*",
+			matches:  []string{"1.2.0", "1.2.1", "1.1.1", "1.3.0"},
+			rejected: []string{"0.0.9", "1.0.0-pre", "2.0.0"},
+		},
+		{
+			input:    "1.2.*",
+			matches:  []string{"1.2.0", "1.2.2", "1.2.4"},
+			rejected: []string{"1.9.0", "1.0.9", "2.0.1", "0.1.3", "1.2.2-pre"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			c, err := ParseCargo(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.input, c.String())
+
+			for _, v := range test.matches {
+				assert.True(t, c.Check(MustNewVersion(v)), v)
+			}
+			for _, v := range test.rejected {
+				assert.False(t, c.Check(MustNewVersion(v)), v)
+			}
+		})
+	}
+}
+
+func TestParseCargo_desugar(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.2.3", expected: ">=1.2.3 <2.0.0"},
+		{input: "0.2.3", expected: ">=0.2.3 <0.3.0"},
+		{input: "^0.0.3", expected: ">=0.0.3 <0.0.4"},
+		{input: "~1.2.3", expected: ">=1.2.3 <1.3.0"},
+		{input: "1.*", expected: ">=1.0.0 <2.0.0"},
+		{input: ">=1.2, <1.5", expected: ">=1.2.0 <1.5.0"},
+		{input: ">1.2", expected: ">=1.3.0"},
+		{input: "<=1.2", expected: "<1.3.0"},
+		{input: "=1.2.3", expected: "=1.2.3"},
+		{input: "1.2.*", expected: ">=1.2.0 <1.3.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			c, err := ParseCargo(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, unwrapConstraint(c).String())
+		})
+	}
+}
+
+func TestParseCargo_error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input       string
+		kind        error
+		expectedErr string
+	}{
+		{input: " ", kind: ErrEmptyInput, expectedErr: "col 1: empty"},
+		{input: "1.2,", kind: ErrSyntax, expectedErr: "col 5: empty comparator"},
+		{input: ">=1.2 || 2", kind: ErrSyntax, expectedErr: `col 3: invalid version "1.2 || 2"`},
+		{input: "v1.2.3", kind: ErrSyntax, expectedErr: `col 1: invalid version "v1.2.3"`},
+		{input: "1, !=1.5", kind: ErrSyntax, expectedErr: `col 4: invalid version "!=1.5"`},
+		{input: "~ 1.2-rc.1", kind: ErrMissingSegment, expectedErr: `col 3: pre-release without patch in "1.2-rc.1"`},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseCargo(test.input)
+			require.EqualError(t, err, test.expectedErr)
+			require.ErrorIs(t, err, test.kind)
+		})
+	}
+}
+
+func TestFormatCargo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "^1.2.3", expected: "1.2.3"},
+		{input: "^0.2.3", expected: "0.2.3"},
+		{input: "~1.2.3", expected: "~1.2.3"},
+		{input: "=1.x", expected: "1.0.0"},
+		{input: "=1.2.x", expected: "~1.2.0"},
+		{input: "=0.0.x", expected: "^0.0"},
+		{input: "=0.x", expected: "^0"},
+		{input: "=1.2.3", expected: "=1.2.3"},
+		{input: ">=0.0.0", expected: ">=0.0.0"},
+		{input: ">=1.2.3", expected: ">=1.2.3"},
+		{input: ">1.2.3", expected: ">1.2.3"},
+		{input: "<=1.2.3", expected: "<=1.2.3"},
+		{input: ">=1.2 <1.5", expected: ">=1.2.0, <1.5.0"},
+		{input: "1.2 - 1.4.x", expected: ">=1.2.0, <1.5.0"},
+		{input: "^1.2.3-rc.1", expected: "1.2.3-rc.1"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			s, err := FormatCargo(MustNewConstraint(test.input))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, s)
+		})
+	}
+}
+
+func TestFormatCargo_roundTrip(t *testing.T) {
+	t.Parallel()
+	for _, input := range []string{
+		"1.2.3", "0.0.3", "^0.0", "^0", "~1.2.3", "=1.2.3", "*",
+		">=1.2.3, <1.5.0", ">1.2.3", "<2.0.0",
+	} {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			c, err := ParseCargo(input)
+			require.NoError(t, err)
+			s, err := FormatCargo(c)
+			require.NoError(t, err)
+			assert.Equal(t, input, s)
+		})
+	}
+}
+
+func TestFormatCargo_notExpressible(t *testing.T) {
+	t.Parallel()
+	for _, c := range []Constraint{
+		MustNewConstraint("~1.2 || ~1.4"),
+		MustNewConstraint("!=1.2.3"),
+		RangeSet{},
+	} {
+		t.Run(c.String(), func(t *testing.T) {
+			t.Parallel()
+			_, err := FormatCargo(c)
+			require.ErrorIs(t, err, ErrNotExpressible)
+		})
+	}
+}
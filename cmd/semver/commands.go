@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"pkg.package-operator.run/semver"
+)
+
+// validationResult is printed per input by validate -json.
+type validationResult struct {
+	Input   string          `json:"input"`
+	Valid   bool            `json:"valid"`
+	Version *semver.Version `json:"version,omitempty"`
+	Column  int             `json:"column,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func validate(e *env, args []string) error {
+	f := newFlags(e, "validate")
+	if err := f.parse(args); err != nil {
+		return err
+	}
+	inputs, err := f.inputs(e)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return usageError{msg: "no versions given"}
+	}
+
+	results := make([]validationResult, len(inputs))
+	var failed bool
+	for i, in := range inputs {
+		results[i].Input = in
+		v, err := f.parseVersion(in)
+		if err != nil {
+			failed = true
+			results[i].Error = err.Error()
+			var perr *semver.ParseError
+			if errors.As(err, &perr) {
+				results[i].Column = perr.Pos
+			}
+			if !f.json {
+				reportParseError(e.stderr, in, err)
+			}
+			continue
+		}
+		results[i].Valid = true
+		results[i].Version = &v
+	}
+
+	if f.json {
+		if err := printJSON(e.stdout, results); err != nil {
+			return err
+		}
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+func compare(e *env, args []string) error {
+	f := newFlags(e, "compare")
+	if err := f.parse(args); err != nil {
+		return err
+	}
+	if f.NArg() != 2 {
+		return usageError{msg: fmt.Sprintf("expected 2 versions, got %d", f.NArg())}
+	}
+	versions, err := f.versions(e)
+	if err != nil {
+		return err
+	}
+
+	a, b := versions[0], versions[1]
+	result := a.Compare(b)
+	if f.json {
+		return printJSON(e.stdout, struct {
+			A      semver.Version `json:"a"`
+			B      semver.Version `json:"b"`
+			Result int            `json:"result"`
+		}{A: a, B: b, Result: result})
+	}
+	_, err = fmt.Fprintln(e.stdout, result)
+	return err
+}
+
+func sortVersions(e *env, args []string) error {
+	f := newFlags(e, "sort")
+	var reverse bool
+	f.BoolVar(&reverse, "r", false, "sort in descending order")
+	if err := f.parse(args); err != nil {
+		return err
+	}
+	versions, err := f.versions(e)
+	if err != nil {
+		return err
+	}
+
+	if reverse {
+		sort.Stable(semver.Descending(versions))
+	} else {
+		sort.Stable(semver.Ascending(versions))
+	}
+	return printVersions(e, f, versions)
+}
+
+// printVersions prints one version per line, or a JSON array.
+func printVersions(e *env, f *flags, versions []semver.Version) error {
+	if f.json {
+		if versions == nil {
+			versions = []semver.Version{}
+		}
+		return printJSON(e.stdout, versions)
+	}
+	for _, v := range versions {
+		if _, err := fmt.Fprintln(e.stdout, v.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// satisfiesResult is printed per version by satisfies -json.
+type satisfiesResult struct {
+	Version   semver.Version `json:"version"`
+	Satisfies bool           `json:"satisfies"`
+}
+
+func satisfies(e *env, args []string) error {
+	f := newConstraintFlags(e, "satisfies")
+	c, err := f.parse(args)
+	if err != nil {
+		return err
+	}
+	versions, err := f.versions(e)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return usageError{msg: "no versions given"}
+	}
+
+	results := make([]satisfiesResult, len(versions))
+	var failed bool
+	for i, v := range versions {
+		results[i] = satisfiesResult{Version: v, Satisfies: c.Check(v)}
+		if results[i].Satisfies {
+			continue
+		}
+		failed = true
+		if !f.json {
+			fmt.Fprintln(e.stderr, semver.Explain(c, v).String())
+		}
+	}
+
+	if f.json {
+		if err := printJSON(e.stdout, results); err != nil {
+			return err
+		}
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+func filter(e *env, args []string) error {
+	f := newConstraintFlags(e, "filter")
+	c, err := f.parse(args)
+	if err != nil {
+		return err
+	}
+	versions, err := f.versions(e)
+	if err != nil {
+		return err
+	}
+
+	matching := semver.VersionList(versions).Filter(c)
+	if err := printVersions(e, f.flags, matching); err != nil {
+		return err
+	}
+	if len(matching) == 0 {
+		return errFailed
+	}
+	return nil
+}
+
+func maxSatisfying(e *env, args []string) error {
+	f := newConstraintFlags(e, "max")
+	c, err := f.parse(args)
+	if err != nil {
+		return err
+	}
+	versions, err := f.versions(e)
+	if err != nil {
+		return err
+	}
+
+	highest, found := semver.VersionList(versions).MaxSatisfying(c)
+	if !found {
+		return fmt.Errorf("no version satisfies %s", c.String())
+	}
+	if f.json {
+		return printJSON(e.stdout, highest)
+	}
+	_, err = fmt.Fprintln(e.stdout, highest.String())
+	return err
+}
@@ -0,0 +1,267 @@
+// Command semver validates, compares and sorts semantic versions,
+// checks them against constraints and computes the next version from
+// conventional commits for use in shell scripts, e.g. instead of sort -V which orders pre-releases wrongly.
+//
+// Usage:
+//
+//	semver <command> [flags] [versions...]
+//
+// Versions are read from arguments or, if none are given, line by line from stdin.
+//
+// Exit codes:
+//   - 0: success
+//   - 1: invalid versions or a check did not pass
+//   - 2: usage errors
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"pkg.package-operator.run/semver"
+)
+
+const (
+	exitOK    = 0
+	exitFail  = 1
+	exitUsage = 2
+)
+
+// errFailed signals exitFail after the command already reported why.
+var errFailed = errors.New("failed")
+
+// env is passed to every command.
+type env struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+}
+
+type command struct {
+	usage string
+	run   func(e *env, args []string) error
+}
+
+var commands = map[string]command{
+	"validate": {
+		usage: "validate [-lenient] [-json] [versions...]\n\tchecks that all versions are valid",
+		run:   validate,
+	},
+	"compare": {
+		usage: "compare [-lenient] [-json] <a> <b>\n\tprints -1, 0 or 1 if a is lower than, equal to or greater than b",
+		run:   compare,
+	},
+	"sort": {
+		usage: "sort [-r] [-lenient] [-json] [versions...]\n\tprints versions in ascending order, or descending with -r",
+		run:   sortVersions,
+	},
+	"satisfies": {
+		usage: "satisfies [-pre-explicit] [-lenient] [-json] <constraint> [versions...]\n" +
+			"\tchecks that all versions satisfy the constraint",
+		run: satisfies,
+	},
+	"filter": {
+		usage: "filter [-pre-explicit] [-lenient] [-json] <constraint> [versions...]\n" +
+			"\tprints the versions satisfying the constraint, fails if there are none",
+		run: filter,
+	},
+	"max": {
+		usage: "max [-pre-explicit] [-lenient] [-json] <constraint> [versions...]\n" +
+			"\tprints the highest version satisfying the constraint, fails if there is none",
+		run: maxSatisfying,
+	},
+	"next": {
+		usage: "next [-pre channel] [-git path [-since rev]] [-lenient] [-json] <current>\n" +
+			"\tprints the next version following the conventional commits read from stdin or git",
+		run: next,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}))
+}
+
+func run(args []string, e *env) int {
+	if len(args) == 0 {
+		printUsage(e.stderr)
+		return exitUsage
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(e.stdout)
+		return exitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(e.stderr, "semver: unknown command %q\n", args[0])
+		printUsage(e.stderr)
+		return exitUsage
+	}
+
+	err := cmd.run(e, args[1:])
+	var uerr usageError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.As(err, &uerr):
+		fmt.Fprintf(e.stderr, "semver %s: %s\nusage: semver %s\n", args[0], err, cmd.usage)
+		return exitUsage
+	case errors.Is(err, errFailed):
+		return exitFail
+	default:
+		fmt.Fprintf(e.stderr, "semver %s: %s\n", args[0], err)
+		return exitFail
+	}
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: semver <command> [flags] [versions...]")
+	fmt.Fprintln(w, "\nVersions are read from arguments or line by line from stdin.\n\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", commands[name].usage)
+	}
+}
+
+// usageError is reported together with the usage of the command.
+type usageError struct{ msg string }
+
+func (e usageError) Error() string { return e.msg }
+
+// flags common to all commands.
+type flags struct {
+	*flag.FlagSet
+	lenient bool
+	json    bool
+}
+
+func newFlags(e *env, name string) *flags {
+	f := &flags{FlagSet: flag.NewFlagSet(name, flag.ContinueOnError)}
+	f.SetOutput(e.stderr)
+	f.BoolVar(&f.lenient, "lenient", false, "accept v prefixes, missing minor/patch numbers and leading zeros")
+	f.BoolVar(&f.json, "json", false, "print results as JSON")
+	return f
+}
+
+func (f *flags) parse(args []string) error {
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return usageError{msg: err.Error()}
+	}
+	return nil
+}
+
+func (f *flags) parseVersion(s string) (semver.Version, error) {
+	if f.lenient {
+		return semver.NewVersion(s, semver.Lenient)
+	}
+	return semver.NewVersion(s)
+}
+
+// constraintFlags are used by commands checking versions against a constraint.
+type constraintFlags struct {
+	*flags
+	preExplicit bool
+}
+
+func newConstraintFlags(e *env, name string) *constraintFlags {
+	f := &constraintFlags{flags: newFlags(e, name)}
+	f.BoolVar(&f.preExplicit, "pre-explicit", false,
+		"only allow pre-releases opted into by a bound with the same major.minor.patch, like npm")
+	return f
+}
+
+// parse parses flags and the constraint as first argument,
+// leaving the remaining arguments as versions.
+func (f *constraintFlags) parse(args []string) (semver.Constraint, error) {
+	if err := f.flags.parse(args); err != nil {
+		return nil, err
+	}
+	if f.NArg() == 0 {
+		return nil, usageError{msg: "missing constraint"}
+	}
+	var opts []semver.ConstraintOption
+	if f.preExplicit {
+		opts = append(opts, semver.PreReleaseExplicit)
+	}
+	c, err := semver.NewConstraint(f.Arg(0), opts...)
+	if err != nil {
+		msg := fmt.Sprintf("invalid constraint %q: %s", f.Arg(0), err)
+		var perr *semver.ParseError
+		if errors.As(err, &perr) {
+			msg += "\n" + perr.Snippet()
+		}
+		return nil, usageError{msg: msg}
+	}
+	// versions and further flags follow the constraint.
+	if err := f.Parse(f.Args()[1:]); err != nil {
+		return nil, usageError{msg: err.Error()}
+	}
+	return c, nil
+}
+
+// inputs returns the positional arguments, or non-empty lines of stdin if there are none.
+func (f *flags) inputs(e *env) ([]string, error) {
+	if f.NArg() > 0 {
+		return f.Args(), nil
+	}
+	var lines []string
+	s := bufio.NewScanner(e.stdin)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines, s.Err()
+}
+
+// versions parses all inputs and reports every invalid one.
+func (f *flags) versions(e *env) ([]semver.Version, error) {
+	inputs, err := f.inputs(e)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]semver.Version, 0, len(inputs))
+	var failed bool
+	for _, in := range inputs {
+		v, err := f.parseVersion(in)
+		if err != nil {
+			reportParseError(e.stderr, in, err)
+			failed = true
+			continue
+		}
+		versions = append(versions, v)
+	}
+	if failed {
+		return nil, errFailed
+	}
+	return versions, nil
+}
+
+// reportParseError prints the error with a caret under the failing column.
+func reportParseError(w io.Writer, input string, err error) {
+	fmt.Fprintf(w, "invalid version %q: %s\n", input, err)
+	var perr *semver.ParseError
+	if errors.As(err, &perr) {
+		fmt.Fprintf(w, "%s\n", perr.Snippet())
+	}
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
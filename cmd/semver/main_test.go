@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name: "validate",
+			args: []string{"validate", "1.2.3", "1.2.3-rc.1+meta"},
+			code: exitOK,
+		},
+		{
+			name:   "validate invalid",
+			args:   []string{"validate", "1.2.3", "1.2"},
+			code:   exitFail,
+			stderr: "invalid version \"1.2\": col 4: missing patch\n1.2\n   ^\n",
+		},
+		{
+			name: "validate lenient",
+			args: []string{"validate", "-lenient", "v1.2"},
+			code: exitOK,
+		},
+		{
+			name:  "validate stdin",
+			args:  []string{"validate"},
+			stdin: "1.2.3\n\n2.0.0\n",
+			code:  exitOK,
+		},
+		{
+			name: "validate json",
+			args: []string{"validate", "-json", "1.2.3", "1.2"},
+			code: exitFail,
+			stdout: `[
+  {
+    "input": "1.2.3",
+    "valid": true,
+    "version": "1.2.3"
+  },
+  {
+    "input": "1.2",
+    "valid": false,
+    "column": 4,
+    "error": "col 4: missing patch"
+  }
+]
+`,
+		},
+		{
+			name:   "compare",
+			args:   []string{"compare", "1.2.3-rc.1", "1.2.3"},
+			code:   exitOK,
+			stdout: "-1\n",
+		},
+		{
+			name:   "compare ignores build metadata",
+			args:   []string{"compare", "1.2.3+build.1", "1.2.3+build.2"},
+			code:   exitOK,
+			stdout: "0\n",
+		},
+		{
+			name:   "compare json",
+			args:   []string{"compare", "-json", "2.0.0", "1.2.3"},
+			code:   exitOK,
+			stdout: "{\n  \"a\": \"2.0.0\",\n  \"b\": \"1.2.3\",\n  \"result\": 1\n}\n",
+		},
+		{
+			name:   "compare missing version",
+			args:   []string{"compare", "1.2.3"},
+			code:   exitUsage,
+			stderr: "semver compare: expected 2 versions, got 1\n",
+		},
+		{
+			name:   "sort",
+			args:   []string{"sort", "1.10.0", "1.2.0", "1.2.0-rc.1", "1.2.0-beta.2", "1.2.0-beta.10"},
+			code:   exitOK,
+			stdout: "1.2.0-beta.2\n1.2.0-beta.10\n1.2.0-rc.1\n1.2.0\n1.10.0\n",
+		},
+		{
+			name:   "sort reverse stdin",
+			args:   []string{"sort", "-r", "-lenient"},
+			stdin:  "v1.2\nv1.10\nv1.2.0-rc.1\n",
+			code:   exitOK,
+			stdout: "1.10.0\n1.2.0\n1.2.0-rc.1\n",
+		},
+		{
+			name:   "sort json",
+			args:   []string{"sort", "-json", "2.0.0", "1.0.0"},
+			code:   exitOK,
+			stdout: "[\n  \"1.0.0\",\n  \"2.0.0\"\n]\n",
+		},
+		{
+			name:   "sort invalid",
+			args:   []string{"sort", "2.0.0", "v1.0.0"},
+			code:   exitFail,
+			stderr: "invalid version \"v1.0.0\"",
+		},
+		{
+			name: "satisfies",
+			args: []string{"satisfies", "~1.2 || >=2", "1.2.5", "2.1.0"},
+			code: exitOK,
+		},
+		{
+			name:   "satisfies rejected",
+			args:   []string{"satisfies", "~1.2 || >=2", "1.2.5", "1.4.0"},
+			code:   exitFail,
+			stderr: "1.4.0 does not satisfy ~1.2 || >=2\n  ~1.2 rejects 1.4.0: not <=1.2.x\n  >=2 rejects 1.4.0: not >=2.0.0\n",
+		},
+		{
+			name: "satisfies json",
+			args: []string{"satisfies", "-json", "^1.2", "1.4.0", "2.0.0"},
+			code: exitFail,
+			stdout: `[
+  {
+    "version": "1.4.0",
+    "satisfies": true
+  },
+  {
+    "version": "2.0.0",
+    "satisfies": false
+  }
+]
+`,
+		},
+		{
+			name: "satisfies pre-explicit",
+			args: []string{"satisfies", "-pre-explicit", ">=1.2.0", "1.3.0-rc.1"},
+			code: exitFail,
+		},
+		{
+			name:   "satisfies invalid constraint",
+			args:   []string{"satisfies", ">=1.3 && <1", "1.2.0"},
+			code:   exitUsage,
+			stderr: "semver satisfies: invalid constraint \">=1.3 && <1\": col 11: over-constrained",
+		},
+		{
+			name:   "satisfies missing constraint",
+			args:   []string{"satisfies"},
+			code:   exitUsage,
+			stderr: "semver satisfies: missing constraint\n",
+		},
+		{
+			name:   "filter stdin",
+			args:   []string{"filter", "~1.2"},
+			stdin:  "1.1.0\n1.2.0\n1.3.0\n1.2.7\n",
+			code:   exitOK,
+			stdout: "1.2.0\n1.2.7\n",
+		},
+		{
+			name:   "filter flags after constraint",
+			args:   []string{"filter", ">=1.2", "-lenient", "v1.1", "v1.3"},
+			code:   exitOK,
+			stdout: "1.3.0\n",
+		},
+		{
+			name:   "filter none",
+			args:   []string{"filter", "-json", "~1.2", "1.1.0"},
+			code:   exitFail,
+			stdout: "[]\n",
+		},
+		{
+			name:   "max",
+			args:   []string{"max", "^1.2", "1.2.0", "1.9.1", "2.0.0", "1.10.0-rc.1"},
+			code:   exitOK,
+			stdout: "1.10.0-rc.1\n",
+		},
+		{
+			name:   "max pre-explicit",
+			args:   []string{"max", "-pre-explicit", "^1.2", "1.2.0", "1.9.1", "2.0.0", "1.10.0-rc.1"},
+			code:   exitOK,
+			stdout: "1.9.1\n",
+		},
+		{
+			name:   "max none",
+			args:   []string{"max", "^3", "1.2.0", "2.0.0"},
+			code:   exitFail,
+			stderr: "semver max: no version satisfies ^3\n",
+		},
+		{
+			name:   "unknown command",
+			args:   []string{"explode"},
+			code:   exitUsage,
+			stderr: "semver: unknown command \"explode\"",
+		},
+		{
+			name:   "no command",
+			code:   exitUsage,
+			stderr: "usage: semver <command>",
+		},
+		{
+			name:   "unknown flag",
+			args:   []string{"sort", "-x"},
+			code:   exitUsage,
+			stderr: "flag provided but not defined: -x",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			code := run(test.args, &env{
+				stdin:  strings.NewReader(test.stdin),
+				stdout: &stdout,
+				stderr: &stderr,
+			})
+			assert.Equal(t, test.code, code, stderr.String())
+			assert.Equal(t, test.stdout, stdout.String())
+			assert.Contains(t, stderr.String(), test.stderr)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"pkg.package-operator.run/semver"
+	"pkg.package-operator.run/semver/gitversions"
+)
+
+// bump is the kind of release a commit requires.
+type bump int
+
+const (
+	bumpNone bump = iota
+	bumpPatch
+	bumpMinor
+	bumpMajor
+)
+
+func (b bump) String() string {
+	switch b {
+	case bumpPatch:
+		return "patch"
+	case bumpMinor:
+		return "minor"
+	case bumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b bump) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// conventionalCommitRegexp matches the subject of conventional commits,
+// e.g. feat(parser)!: drop support for x.
+var conventionalCommitRegexp = regexp.MustCompile(`^([a-zA-Z]+)(\([^()]*\))?(!)?: `)
+
+// commit is a commit message, optionally with the hash it was read from.
+type commit struct {
+	Hash    string `json:"hash,omitempty"`
+	Subject string `json:"subject"`
+	body    string
+}
+
+// bump returns the kind of release the commit requires following conventional commits.
+func (c commit) bump() bump {
+	for line := range strings.Lines(c.body) {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			return bumpMajor
+		}
+	}
+	m := conventionalCommitRegexp.FindStringSubmatch(c.Subject)
+	switch {
+	case m == nil:
+		return bumpNone
+	case len(m[3]) > 0:
+		return bumpMajor
+	}
+	switch strings.ToLower(m[1]) {
+	case "feat":
+		return bumpMinor
+	case "fix":
+		return bumpPatch
+	}
+	return bumpNone
+}
+
+// nextResult is printed by next -json.
+type nextResult struct {
+	Current semver.Version `json:"current"`
+	Next    semver.Version `json:"next"`
+	Bump    bump           `json:"bump"`
+	Commit  commit         `json:"commit"`
+}
+
+func next(e *env, args []string) error {
+	f := newFlags(e, "next")
+	var pre, repo, since string
+	f.StringVar(&pre, "pre", "", "create a pre-release on the given channel, e.g. rc")
+	f.StringVar(&repo, "git", "", "read commits from the git repository at the given path instead of stdin")
+	f.StringVar(&since, "since", "",
+		"with -git, read commits after this revision, defaults to the tag of the current version with or without v prefix")
+	if err := f.parse(args); err != nil {
+		return err
+	}
+	if f.NArg() != 1 {
+		return usageError{msg: fmt.Sprintf("expected the current version, got %d arguments", f.NArg())}
+	}
+	current, err := f.parseVersion(f.Arg(0))
+	if err != nil {
+		reportParseError(e.stderr, f.Arg(0), err)
+		return errFailed
+	}
+
+	var commits []commit
+	if len(repo) > 0 {
+		if len(since) == 0 {
+			since, err = versionTag(repo, current)
+			if err != nil {
+				return err
+			}
+		}
+		commits, err = gitCommits(repo, since)
+	} else {
+		commits, err = readCommits(e)
+	}
+	if err != nil {
+		return err
+	}
+
+	res := nextResult{Current: current}
+	for _, c := range commits {
+		// the first commit requiring the largest bump drives the decision.
+		if b := c.bump(); b > res.Bump {
+			res.Bump, res.Commit = b, c
+		}
+	}
+	if res.Bump == bumpNone {
+		return fmt.Errorf("none of %d commits requires a release", len(commits))
+	}
+	if current.Major == 0 && res.Bump == bumpMajor {
+		// breaking changes before 1.0.0 only bump minor.
+		res.Bump = bumpMinor
+	}
+
+	res.Next, err = nextVersion(current, res.Bump, pre)
+	if err != nil {
+		return err
+	}
+
+	if f.json {
+		return printJSON(e.stdout, res)
+	}
+	driver := res.Commit.Subject
+	if len(res.Commit.Hash) > 0 {
+		driver = shortHash(res.Commit.Hash) + " " + driver
+	}
+	fmt.Fprintf(e.stderr, "%s bump due to %s\n", res.Bump, driver)
+	_, err = fmt.Fprintln(e.stdout, res.Next.String())
+	return err
+}
+
+// nextVersion applies the bump to the current version.
+// With a pre-release channel, pre-releases continue counting as long as
+// their release already includes the bump, e.g. 1.3.0-rc.0 with a fix => 1.3.0-rc.1.
+func nextVersion(current semver.Version, b bump, pre string) (semver.Version, error) {
+	if len(pre) > 0 && len(current.PreRelease) > 0 && b <= releaseBump(current) {
+		return current.IncPreRelease(pre)
+	}
+
+	var (
+		next semver.Version
+		err  error
+	)
+	switch b {
+	case bumpMajor:
+		next, err = current.IncMajor()
+	case bumpMinor:
+		next, err = current.IncMinor()
+	default:
+		next, err = current.IncPatch()
+	}
+	if err != nil || len(pre) == 0 {
+		return next, err
+	}
+	if next.Equal(current.Release()) {
+		// the pre-release was promoted, but a new pre-release is requested.
+		return current.IncPreRelease(pre)
+	}
+	// start counting on the channel, e.g. 1.3.0-rc.0.
+	return semver.NewVersion(next.String() + "-" + pre + ".0")
+}
+
+// releaseBump returns the kind of release the pre-release is heading for,
+// e.g. minor for 1.3.0-rc.1 and patch for 1.2.4-rc.1.
+func releaseBump(v semver.Version) bump {
+	switch {
+	case v.Patch > 0:
+		return bumpPatch
+	case v.Minor > 0 || v.Major == 0:
+		return bumpMinor
+	}
+	return bumpMajor
+}
+
+// readCommits reads one commit subject per line from stdin.
+func readCommits(e *env) ([]commit, error) {
+	var commits []commit
+	s := bufio.NewScanner(e.stdin)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); len(line) > 0 {
+			commits = append(commits, commit{Subject: line, body: line})
+		}
+	}
+	return commits, s.Err()
+}
+
+// gitCommits reads commits from newest to oldest after the given revision, or all commits without revision.
+func gitCommits(repo, since string) ([]commit, error) {
+	args := []string{"-C", repo, "log", "--format=%H%x1f%s%x1f%b%x1e"}
+	if len(since) > 0 {
+		args = append(args, since+"..HEAD")
+	}
+	out, err := git(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []commit
+	for record := range strings.SplitSeq(string(out), "\x1e") {
+		parts := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		commits = append(commits, commit{Hash: parts[0], Subject: parts[1], body: parts[2]})
+	}
+	return commits, nil
+}
+
+// versionTag returns the name of the tag of the version with or without v prefix,
+// or an empty string if the repository has no such tag.
+func versionTag(repo string, v semver.Version) (string, error) {
+	res, err := gitversions.Read(repo, gitversions.Options{
+		ParseOptions: semver.ParseOptions{AllowVPrefix: true},
+	})
+	if err != nil {
+		return "", err
+	}
+	tag, _ := res.TagName(v)
+	return tag, nil
+}
+
+func git(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && stderr.Len() > 0 {
+		return nil, fmt.Errorf("git %s: %s", args[2], strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
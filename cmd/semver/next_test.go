@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pkg.package-operator.run/semver"
+)
+
+func TestCommit_bump(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		subject  string
+		body     string
+		expected bump
+	}{
+		{subject: "feat: add x", expected: bumpMinor},
+		{subject: "feat(parser): add x", expected: bumpMinor},
+		{subject: "Feat: add x", expected: bumpMinor},
+		{subject: "fix: y", expected: bumpPatch},
+		{subject: "fix!: y", expected: bumpMajor},
+		{subject: "refactor(api)!: drop z", expected: bumpMajor},
+		{subject: "fix: y", body: "details\n\nBREAKING CHANGE: removes z", expected: bumpMajor},
+		{subject: "chore: update deps", expected: bumpNone},
+		{subject: "feature: add x", expected: bumpNone},
+		{subject: "Merge branch 'main'", expected: bumpNone},
+		{subject: "feat:missing space", expected: bumpNone},
+	}
+	for _, test := range tests {
+		t.Run(test.subject, func(t *testing.T) {
+			t.Parallel()
+			c := commit{Subject: test.subject, body: test.body}
+			assert.Equal(t, test.expected, c.bump())
+		})
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		current  string
+		bump     bump
+		pre      string
+		expected string
+	}{
+		{current: "1.2.3", bump: bumpPatch, expected: "1.2.4"},
+		{current: "1.2.3", bump: bumpMinor, expected: "1.3.0"},
+		{current: "1.2.3", bump: bumpMajor, expected: "2.0.0"},
+		{current: "1.3.0-rc.1", bump: bumpPatch, expected: "1.3.0"},
+		{current: "1.3.0-rc.1", bump: bumpMajor, expected: "2.0.0"},
+
+		{current: "1.2.3", bump: bumpPatch, pre: "rc", expected: "1.2.4-rc.0"},
+		{current: "1.2.3", bump: bumpMinor, pre: "rc", expected: "1.3.0-rc.0"},
+		{current: "1.3.0-rc.0", bump: bumpPatch, pre: "rc", expected: "1.3.0-rc.1"},
+		{current: "1.3.0-rc.0", bump: bumpMinor, pre: "rc", expected: "1.3.0-rc.1"},
+		{current: "1.3.0-beta.2", bump: bumpPatch, pre: "rc", expected: "1.3.0-rc.0"},
+		{current: "1.3.0-rc.0", bump: bumpMajor, pre: "rc", expected: "2.0.0-rc.0"},
+		{current: "1.2.4-rc.0", bump: bumpMinor, pre: "rc", expected: "1.3.0-rc.0"},
+		{current: "0.3.0-rc.0", bump: bumpMinor, pre: "rc", expected: "0.3.0-rc.1"},
+	}
+	for _, test := range tests {
+		t.Run(test.current+" "+test.bump.String()+" "+test.pre, func(t *testing.T) {
+			t.Parallel()
+			next, err := nextVersion(semver.MustNewVersion(test.current), test.bump, test.pre)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, next.String())
+		})
+	}
+
+	_, err := nextVersion(semver.MustNewVersion("1.2.3"), bumpMinor, "r c")
+	require.Error(t, err)
+}
+
+func TestRun_next(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name:   "feature",
+			args:   []string{"next", "1.2.3"},
+			stdin:  "fix: a\nfeat: b\nchore: c\nfeat: d\n",
+			code:   exitOK,
+			stdout: "1.3.0\n",
+			stderr: "minor bump due to feat: b\n",
+		},
+		{
+			name:   "breaking",
+			args:   []string{"next", "-lenient", "v1.2"},
+			stdin:  "fix: a\nfeat!: b\n",
+			code:   exitOK,
+			stdout: "2.0.0\n",
+		},
+		{
+			name:   "breaking footer",
+			args:   []string{"next", "1.2.3"},
+			stdin:  "fix: a\nBREAKING CHANGE: b\n",
+			code:   exitOK,
+			stdout: "2.0.0\n",
+		},
+		{
+			name:   "breaking before 1.0.0",
+			args:   []string{"next", "0.4.2"},
+			stdin:  "feat!: b\n",
+			code:   exitOK,
+			stdout: "0.5.0\n",
+			stderr: "minor bump due to feat!: b\n",
+		},
+		{
+			name:   "pre-release",
+			args:   []string{"next", "-pre", "rc", "1.2.3"},
+			stdin:  "fix: a\n",
+			code:   exitOK,
+			stdout: "1.2.4-rc.0\n",
+		},
+		{
+			name:  "json",
+			args:  []string{"next", "-json", "1.2.3"},
+			stdin: "fix: a\n",
+			code:  exitOK,
+			stdout: `{
+  "current": "1.2.3",
+  "next": "1.2.4",
+  "bump": "patch",
+  "commit": {
+    "subject": "fix: a"
+  }
+}
+`,
+		},
+		{
+			name:   "no release",
+			args:   []string{"next", "1.2.3"},
+			stdin:  "chore: a\ndocs: b\n",
+			code:   exitFail,
+			stderr: "semver next: none of 2 commits requires a release\n",
+		},
+		{
+			name:   "missing version",
+			args:   []string{"next"},
+			code:   exitUsage,
+			stderr: "semver next: expected the current version, got 0 arguments\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			code := run(test.args, &env{
+				stdin:  strings.NewReader(test.stdin),
+				stdout: &stdout,
+				stderr: &stderr,
+			})
+			assert.Equal(t, test.code, code, stderr.String())
+			assert.Equal(t, test.stdout, stdout.String())
+			assert.Contains(t, stderr.String(), test.stderr)
+		})
+	}
+}
+
+func TestRun_nextGit(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	repo := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	gitCmd("init", "-q")
+	gitCmd("commit", "-q", "--allow-empty", "-m", "feat!: initial")
+	gitCmd("tag", "v1.2.3")
+	gitCmd("commit", "-q", "--allow-empty", "-m", "fix: a")
+	gitCmd("tag", "1.2.4")
+	gitCmd("commit", "-q", "--allow-empty", "-m", "feat(cli): b", "-m", "adds b")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"next", "-git", repo, "-lenient", "v1.2.3"}, &env{stdout: &stdout, stderr: &stderr})
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Equal(t, "1.3.0\n", stdout.String())
+	assert.Regexp(t, `^minor bump due to [0-9a-f]{7} feat\(cli\): b\n$`, stderr.String())
+
+	// tags are found with and without v prefix.
+	for _, current := range []string{"1.2.3", "1.2.4"} {
+		stdout.Reset()
+		stderr.Reset()
+		code = run([]string{"next", "-git", repo, current}, &env{stdout: &stdout, stderr: &stderr})
+		require.Equal(t, exitOK, code, stderr.String())
+		assert.Equal(t, "1.3.0\n", stdout.String(), current)
+	}
+
+	// without tag all commits are read.
+	stdout.Reset()
+	stderr.Reset()
+	code = run([]string{"next", "-git", repo, "1.2.2"}, &env{stdout: &stdout, stderr: &stderr})
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Equal(t, "2.0.0\n", stdout.String())
+
+	stderr.Reset()
+	code = run([]string{"next", "-git", repo, "-since", "nope", "1.2.3"}, &env{stdout: &stdout, stderr: &stderr})
+	assert.Equal(t, exitFail, code)
+	assert.Contains(t, stderr.String(), "semver next: git log:")
+}
@@ -0,0 +1,147 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoVersion is returned by Coerce, if the input does not contain a version-like substring.
+var ErrNoVersion = errors.New("no version found")
+
+// Span is a byte range [Start, End) within an input string.
+type Span struct {
+	Start, End int
+}
+
+// Len returns the number of bytes within the Span.
+func (s Span) Len() int {
+	return s.End - s.Start
+}
+
+// CoerceOptions control how versions are extracted by Coerce.
+type CoerceOptions struct {
+	// Last uses the last version-like substring instead of the first.
+	Last bool
+}
+
+// CoerceOption can be passed to Coerce.
+type CoerceOption interface {
+	ApplyToCoerceOptions(opts *CoerceOptions)
+}
+
+// CoerceLast makes Coerce use the last version-like substring instead of the first.
+// Numbers following a version with a dot, like the 4 of 1.2.3.4, are not a version on their own.
+type CoerceLast struct{}
+
+// ApplyToCoerceOptions implements CoerceOption.
+func (CoerceLast) ApplyToCoerceOptions(opts *CoerceOptions) {
+	opts.Last = true
+}
+
+// matches 1, 1.2 and 1.2.3 with optional pre-release and build suffixes.
+var coerceRegexp = regexp.MustCompile(
+	`(\d+)(?:\.(\d+))?(?:\.(\d+))?` +
+		`(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?` +
+		`(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?`)
+
+// Coerce extracts a version from an arbitrary string,
+// e.g. release-1.4 => 1.4.0, nginx-1.25.3-alpine => 1.25.3-alpine or go1.22.1 => 1.22.1.
+// Missing minor and patch numbers are filled with 0 and leading zeros are dropped.
+// Pre-release and build suffixes are kept, when they are well-formed.
+func Coerce(s string, opts ...CoerceOption) (Version, error) {
+	v, _, err := CoerceSpan(s, opts...)
+	return v, err
+}
+
+// CoerceSpan works like Coerce and additionally reports the Span of the input the Version was extracted from.
+func CoerceSpan(s string, opts ...CoerceOption) (Version, Span, error) {
+	var options CoerceOptions
+	for _, opt := range opts {
+		opt.ApplyToCoerceOptions(&options)
+	}
+
+	var (
+		found bool
+		v     Version
+		span  Span
+	)
+	for _, m := range coerceRegexp.FindAllStringSubmatchIndex(s, -1) {
+		if continuesNumber(s, m[0]) {
+			// e.g. the 4 of 1.2.3.4 is no version on its own.
+			continue
+		}
+		cv, cspan, ok := coerceMatch(s, m)
+		if !ok {
+			continue
+		}
+		found, v, span = true, cv, cspan
+		if !options.Last {
+			break
+		}
+	}
+	if !found {
+		return Version{}, Span{}, fmt.Errorf("coercing %q: %w", s, ErrNoVersion)
+	}
+	return v, span, nil
+}
+
+// continuesNumber returns true if the match at start follows a dotted number,
+// so it belongs to the same version-like run as the previous match.
+func continuesNumber(s string, start int) bool {
+	return start >= 2 && s[start-1] == '.' && isDigit(rune(s[start-2]))
+}
+
+// coerceMatch converts the submatch indices of coerceRegexp into a Version.
+func coerceMatch(s string, m []int) (v Version, span Span, ok bool) {
+	group := func(i int) (string, bool) {
+		if m[2*i] < 0 {
+			return "", false
+		}
+		return s[m[2*i]:m[2*i+1]], true
+	}
+
+	span = Span{Start: m[0], End: m[1]}
+	nums := [3]*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, num := range nums {
+		str, set := group(i + 1)
+		if !set {
+			break
+		}
+		n, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			// number too large
+			return Version{}, Span{}, false
+		}
+		*num = n
+		span.End = m[2*(i+1)+1]
+	}
+
+	// suffixes are only kept, if all identifiers are valid.
+	if pr, ok := group(4); ok {
+		prParts := strings.Split(pr, ".")
+		for _, part := range prParts {
+			if !isPreReleaseIdentifier(part) {
+				return v, span, true
+			}
+		}
+		for _, part := range prParts {
+			v.PreRelease = append(v.PreRelease, ToPreReleaseIdentifier(part))
+		}
+		span.End = m[9]
+	}
+
+	if build, ok := group(5); ok {
+		buildParts := strings.Split(build, ".")
+		for _, part := range buildParts {
+			if !isBuildIdentifier(part) {
+				return v, span, true
+			}
+		}
+		v.BuildMetadata = buildParts
+		span.End = m[11]
+	}
+	return v, span, true
+}
@@ -0,0 +1,58 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoerce(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		opts     []CoerceOption
+		expected string
+		span     Span
+	}{
+		{input: "1.2.3", expected: "1.2.3", span: Span{0, 5}},
+		{input: "release-1.4", expected: "1.4.0", span: Span{8, 11}},
+		{input: "nginx-1.25.3-alpine", expected: "1.25.3-alpine", span: Span{6, 19}},
+		{input: "go1.22.1", expected: "1.22.1", span: Span{2, 8}},
+		{input: "v2", expected: "2.0.0", span: Span{1, 2}},
+		{input: "v1.02.003", expected: "1.2.3", span: Span{1, 9}},
+		{input: "app-1.2.3-rc.1+build.5.tar", expected: "1.2.3-rc.1+build.5.tar", span: Span{4, 26}},
+		{input: "1.2.3-rc_1", expected: "1.2.3-rc", span: Span{0, 8}},
+		{input: "1.2.3-01+b1", expected: "1.2.3", span: Span{0, 5}},
+		{input: "1.2.3.4", expected: "1.2.3", span: Span{0, 5}},
+		{input: "1.2.3.4", opts: []CoerceOption{CoerceLast{}}, expected: "1.2.3", span: Span{0, 5}},
+		{input: "1.2.3.4 v5.6.7.8", opts: []CoerceOption{CoerceLast{}}, expected: "5.6.7", span: Span{9, 14}},
+		{input: "foo-1.2-bar-3.4", expected: "1.2.0-bar-3.4", span: Span{4, 15}},
+		{input: "foo 1.2 bar 3.4.5", expected: "1.2.0", span: Span{4, 7}},
+		{input: "foo 1.2 bar 3.4.5", opts: []CoerceOption{CoerceLast{}}, expected: "3.4.5", span: Span{12, 17}},
+		{input: "99999999999999999999 1.0", expected: "1.0.0", span: Span{21, 24}},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			v, span, err := CoerceSpan(test.input, test.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v.String())
+			assert.Equal(t, test.span, span)
+
+			cv, err := Coerce(test.input, test.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, v, cv)
+		})
+	}
+}
+
+func TestCoerce_error(t *testing.T) {
+	t.Parallel()
+	for _, input := range []string{"", "latest", "99999999999999999999"} {
+		_, err := Coerce(input)
+		require.ErrorIs(t, err, ErrNoVersion, input)
+	}
+	_, err := Coerce("latest")
+	require.EqualError(t, err, `coercing "latest": no version found`)
+}
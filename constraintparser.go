@@ -10,18 +10,18 @@
 package semver
 
 import (
-	"fmt"
+	"errors"
+	"slices"
 	"sort"
 
 	"pkg.package-operator.run/semver/internal"
-	"pkg.package-operator.run/semver/internal/ranges"
 )
 
 const maxUint64 = ^uint64(0)
 
 // MustNewConstraint parses the given string into a Version Constraint or panics.
-func MustNewConstraint(data string) Constraint {
-	c, err := NewConstraint(data)
+func MustNewConstraint(data string, opts ...ConstraintOption) Constraint {
+	c, err := NewConstraint(data, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -29,106 +29,173 @@ func MustNewConstraint(data string) Constraint {
 }
 
 // NewConstraint parses the given string into a Version Constraint.
-func NewConstraint(data string) (Constraint, error) {
-	c, err := parseConstraint([]byte(data))
-	if err != nil {
-		return nil, err
+// The string is parsed into an AST first, which is then lowered into ranges.
+func NewConstraint(data string, opts ...ConstraintOption) (Constraint, error) {
+	var options ConstraintOptions
+	for _, opt := range opts {
+		opt.ApplyToConstraintOptions(&options)
 	}
-	return &originalInputConstraint{
-		Constraint: c,
-		original:   data,
-	}, nil
+	return newConstraint([]byte(data), options)
 }
 
-// parseConstraint bytes into a Version Constraint.
-func parseConstraint(data []byte) (Constraint, error) {
-	var p parserState
-	p.init(data)
-	c, err := p.parse()
-	if err != nil {
-		return nil, err
-	}
-	return c, nil
+// ConstraintOptions control how constraints are parsed and checked.
+type ConstraintOptions struct {
+	// PreReleasePolicy defines which pre-release versions are allowed.
+	// Defaults to PreReleaseInclude.
+	PreReleasePolicy PreReleasePolicy
+	// RecoverErrors continues parsing after errors at the next || or &&,
+	// to report all errors as ParseErrorList.
+	RecoverErrors bool
 }
 
-type parserState struct {
-	scanner ranges.Scanner
+// ConstraintOption can be passed to NewConstraint.
+type ConstraintOption interface {
+	ApplyToConstraintOptions(opts *ConstraintOptions)
+}
 
-	c Constraint
+// RecoverErrors continues parsing after errors at the next || or &&,
+// to report all errors as ParseErrorList.
+type RecoverErrors struct{}
 
-	or       or           // active || combined ranges or And constraints
-	and      and          // active && combined ranges
-	operator ranges.Token // EQUAL,NOT_EQUAL, GREATER, LESS, GREATER_EQUAL, LESS_EQUAL
+// ApplyToConstraintOptions implements ConstraintOption.
+func (RecoverErrors) ApplyToConstraintOptions(opts *ConstraintOptions) {
+	opts.RecoverErrors = true
+}
 
-	expectingNumber bool     // if we expect a number next
-	lastSemverPos   int      // previous position after versionClose()
-	semverPos       int      // 0=Major, 1=Minor, 2=Patch
-	version         *Version // active version being parsed
-	max             bool     // false = min part of the range, true = max part of the range
-	activeRange     *Range   // active range being parsed
-	errors          []string // scanner errors
+// parseConstraint bytes into a Version Constraint.
+func parseConstraint(data []byte) (Constraint, error) {
+	return newConstraint(data, ConstraintOptions{})
 }
 
-func (p *parserState) init(src []byte) *parserState {
-	p.scanner.Init(src, func(pos internal.Position, msg string) {
-		p.errors = append(p.errors, fmt.Sprintf("%s: %s", pos, msg))
-	})
-	p.resetRange()
-	return p
+func newConstraint(src []byte, options ConstraintOptions) (Constraint, error) {
+	var p astParser
+	p.init(src)
+	p.recoverErrors = options.RecoverErrors
+	ast, err := p.parse()
+	return ast.constraint(options, err)
 }
 
-func (p *parserState) addNumberToVersion(num uint64) {
-	if p.activeRange == nil {
-		p.activeRange = &Range{}
+// constraint lowers the AST and merges errors with
+// the syntax errors encountered while parsing it.
+func (a *AST) constraint(options ConstraintOptions, syntaxErr error) (Constraint, error) {
+	src := []byte(a.Input)
+	l := lowerer{recoverErrors: options.RecoverErrors, preReleasePolicy: options.PreReleasePolicy, src: src}
+	c, err := l.lower(a)
+	if err != nil {
+		// lowered clauses were closed before any syntax error.
+		return nil, withInput(err, src)
+	}
+
+	if options.RecoverErrors {
+		var diagnostics ParseErrorList
+		errors.As(syntaxErr, &diagnostics)
+		diagnostics = append(slices.Clone(diagnostics), l.diagnostics...)
+		if len(diagnostics) > 0 {
+			slices.SortStableFunc(diagnostics, func(a, b *ParseError) int {
+				return a.Pos - b.Pos
+			})
+			return nil, diagnostics
+		}
+	}
+	if syntaxErr != nil {
+		return nil, syntaxErr
 	}
-	if p.max {
-		p.version = &p.activeRange.Max
-	} else {
-		p.version = &p.activeRange.Min
+	if c == nil {
+		return nil, withInput(parseErrorf(1, ErrEmptyInput, "empty"), src)
 	}
 
-	switch p.semverPos {
-	case 0:
-		p.version.Major = num
-	case 1:
-		p.version.Minor = num
-	case 2:
-		p.version.Patch = num
+	original := a.Input
+	if len(original) == 0 {
+		original = a.String()
 	}
+	return &originalInputConstraint{
+		Constraint: c,
+		original:   original,
+		ast:        a,
+	}, nil
 }
 
-func (p *parserState) closeVersion(pos internal.Position) error {
-	if p.version == nil {
-		return nil
-	}
-	if p.expectingNumber {
-		// semver clause incomplete!
-		return fmt.Errorf("%s: semver clause incomplete", pos)
-	}
+// lowerer converts an AST into ranges.
+type lowerer struct {
+	src []byte
 
-	p.lastSemverPos = p.semverPos
-	p.semverPos = 0
-	if !p.max {
-		// move to max part of range next.
-		p.max = true
-	}
-	p.version = nil // no active version
-	return nil
+	recoverErrors bool           // continue lowering after errors
+	diagnostics   ParseErrorList // errors collected when recovering
+
+	preReleasePolicy PreReleasePolicy
 }
 
-func (p *parserState) closeRange(pos internal.Position) error {
-	if p.activeRange == nil {
-		return nil
+func (l *lowerer) lower(ast *AST) (Constraint, error) {
+	var o or // || combined ranges or And constraints
+	for _, g := range ast.Or {
+		var (
+			a           and       // && combined ranges
+			preReleases []Version // versions with pre-release used as bounds
+		)
+		for i := range g.Clauses {
+			clause := &g.Clauses[i]
+			c := lowerClause(clause)
+			next, err := compactAndValidateLogicalAND(clause.closePos, append(a, c))
+			if err != nil {
+				if !l.recoverErrors {
+					return nil, err
+				}
+				// drop the clause and keep the previous ones.
+				l.diagnostics = appendDiagnostic(l.diagnostics, err, l.src)
+				continue
+			}
+			a = next
+			if clause.Op == OpNotEqual {
+				// excluded versions do not opt into pre-releases.
+				continue
+			}
+			for _, v := range []*PartialVersion{&clause.Version, &clause.Upper} {
+				if len(v.PreRelease) > 0 {
+					pv, _ := v.lower(0)
+					preReleases = append(preReleases, pv)
+				}
+			}
+		}
+
+		var c Constraint
+		switch len(a) {
+		case 0:
+			continue
+		case 1:
+			c = a[0]
+		default:
+			c = a
+		}
+		if l.preReleasePolicy == PreReleaseExplicit {
+			// every || branch opts into pre-releases on its own, like npm comparator sets.
+			c = &preReleaseConstraint{Constraint: c, preReleases: preReleases}
+		}
+		o = append(o, c)
 	}
-	if err := p.closeVersion(pos); err != nil {
-		return err
+
+	// Compact OR'd ranges if possible
+	o = compactLogicalOR(o)
+
+	switch len(o) {
+	case 0:
+		return nil, nil
+	case 1:
+		return o[0], nil
+	default:
+		return o, nil
 	}
-	r := p.activeRange
+}
 
-	switch p.operator {
-	case ranges.EQUAL, ranges.NOT_EQUAL:
+// lowerClause expands the operator and wildcards of a clause into a range.
+func lowerClause(clause *Clause) Constraint {
+	var r Range
+	lo, lastSemverPos := clause.Version.lower(0)
+	r.Min = lo
+
+	switch clause.Op {
+	case OpEqual, OpNotEqual:
 		r.Max = r.Min
-		switch p.lastSemverPos {
+		switch lastSemverPos {
 		case 0:
 			r.Max.Minor = maxUint64
 			r.Max.Patch = maxUint64
@@ -137,24 +204,23 @@ func (p *parserState) closeRange(pos internal.Position) error {
 			r.Max.Patch = maxUint64
 		}
 
-	case ranges.GREATER:
-		switch p.lastSemverPos {
-		// 1.x.x -> 2.x.x
-		case 0:
-			r.Min.Major++
-
-		// 1.2.x -> 1.3.x
-		case 1:
-			r.Min.Minor++
-
-		// 1.2.0 -> 1.2.1
-		default:
-			r.Min.Patch++
+	case OpGreater:
+		// 1.2.3 -> exclusive 1.2.3
+		// 1.2.x -> exclusive 1.2.x, allowing 1.3.0-rc.1 like >1.2.3 allows 1.2.4-rc.1
+		// 1.x.x -> exclusive 1.x.x
+		if lastSemverPos < 2 {
+			r.Min, _ = clause.Version.lower(maxUint64)
+			r.Min.Patch = maxUint64
+			if lastSemverPos == 0 {
+				r.Min.Minor = maxUint64
+			}
 		}
-		// x.x.x
-		r.Max = Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64}
+		r.MinExclusive = true
+		r.Max = Version{}
+		r.MaxUnbounded = true
 
-	case ranges.HYPHEN:
+	case OpHyphen:
+		r.Max, _ = clause.Upper.lower(maxUint64)
 		// x.0 => x.x
 		if r.Max.Major == maxUint64 {
 			r.Max.Minor = maxUint64
@@ -164,196 +230,51 @@ func (p *parserState) closeRange(pos internal.Position) error {
 			r.Max.Patch = maxUint64
 		}
 
-	case ranges.LESS:
+	case OpLess:
 		r.Max = r.Min
-		switch {
-		// 1.2.0 => 1.1.x
-		case r.Max.Patch == 0 && r.Max.Minor > 0:
-			r.Max.Patch = maxUint64
-			r.Max.Minor--
-		// 1.0.0 => 0.x.x
-		case r.Max.Minor == 0:
-			r.Max.Patch = maxUint64
-			r.Max.Minor = maxUint64
-			r.Max.Major--
-		// 1.2.3 => 1.2.2
-		default:
-			r.Max.Patch--
-		}
-		r.Min = Version{} // 0.0.0
+		r.MaxExclusive = true
+		r.Min = Version{}
+		r.MinUnbounded = true
 
-	case ranges.LESS_EQUAL:
+	case OpLessEqual:
 		r.Max = r.Min
-		r.Min = Version{} // 0.0.0
+		r.Min = Version{}
+		r.MinUnbounded = true
 
-	case ranges.GREATER_EQUAL:
-		r.Max = Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64}
+	case OpGreaterEqual:
+		r.Max = Version{}
+		r.MaxUnbounded = true
 
-	case ranges.TILDE:
-		r.Max = r.Min
+	case OpTilde:
+		r.Max = Version{Major: r.Min.Major, Minor: r.Min.Minor}
 		r.Max.Patch = maxUint64
 		if r.Max.Minor == 0 {
 			r.Max.Minor = maxUint64
 		}
 
-	case ranges.CARET:
-		r.Max = r.Min
+	case OpCaret:
+		r.Max = Version{Major: r.Min.Major, Minor: r.Min.Minor}
 		if r.Min.Major != 0 {
 			r.Max.Minor = maxUint64
 		}
 		r.Max.Patch = maxUint64
-
-	default:
-		return fmt.Errorf("%s: range closed without operator", pos)
 	}
 
-	var c Constraint
-	c = r
 	// negate result
-	if p.operator == ranges.NOT_EQUAL {
-		c = not{Range: *r}
-	}
-
-	p.and = append(p.and, c)
-	var err error
-	p.and, err = compactAndValidateLogicalAND(pos, p.and)
-	if err != nil {
-		return err
+	if clause.Op == OpNotEqual {
+		return not{Range: r}
 	}
-
-	// reset
-	p.resetRange()
-	return nil
-}
-
-func (p *parserState) resetRange() {
-	p.max = false
-	p.activeRange = nil
-	p.operator = 0
+	return &r
 }
 
-func (p *parserState) close(pos internal.Position) error {
-	if err := p.closeRange(pos); err != nil {
-		return err
+// appendDiagnostic records an error in recovering mode.
+func appendDiagnostic(l ParseErrorList, err error, src []byte) ParseErrorList {
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		perr = parseErrorf(0, ErrSyntax, "%s", err.Error())
 	}
-
-	switch len(p.and) {
-	case 0:
-	case 1:
-		p.or = append(p.or, p.and[0])
-	default:
-		p.or = append(p.or, p.and)
-	}
-
-	// Compact OR'd ranges if possible
-	p.or = compactLogicalOR(p.or)
-
-	switch len(p.or) {
-	case 0:
-	case 1:
-		p.c = p.or[0]
-	default:
-		p.c = p.or
-	}
-	return nil
-}
-
-func (p *parserState) parse() (Constraint, error) {
-parse:
-	for {
-		pos, tok, lit := p.scanner.Scan()
-		if len(p.errors) > 0 {
-			return nil, fmt.Errorf("%s", p.errors[0])
-		}
-
-		switch tok {
-		case ranges.ILLEGAL:
-			goto parse
-
-		case ranges.SPACE:
-			if err := p.closeVersion(pos); err != nil {
-				return nil, err
-			}
-
-		case ranges.EQUAL, ranges.NOT_EQUAL,
-			ranges.GREATER, ranges.GREATER_EQUAL,
-			ranges.LESS, ranges.LESS_EQUAL,
-			ranges.TILDE, ranges.CARET:
-			if err := p.closeRange(pos); err != nil {
-				return nil, err
-			}
-			p.operator = tok
-
-		case ranges.AND:
-			if p.activeRange == nil {
-				return nil, fmt.Errorf("%s: AND empty range constraint", pos)
-			}
-			if err := p.closeRange(pos); err != nil {
-				return nil, err
-			}
-
-		case ranges.OR:
-			if p.activeRange == nil {
-				return nil, fmt.Errorf("%s: OR empty range constraint", pos)
-			}
-			if err := p.closeRange(pos); err != nil {
-				return nil, err
-			}
-			// Shift current AND constraint into OR
-			if len(p.and) == 1 {
-				p.or = append(p.or, p.and[0])
-			} else {
-				p.or = append(p.or, p.and)
-			}
-			p.and = nil
-
-		case ranges.HYPHEN:
-			if p.operator == ranges.HYPHEN {
-				// we are already within a HYPON range.
-				// seeing a HYPON again is an error.
-				return nil, fmt.Errorf(`%s: double hyphen in range constraint`, pos)
-			}
-			if err := p.closeVersion(pos); err != nil {
-				return nil, err
-			}
-			p.operator = tok
-			p.max = true
-			p.expectingNumber = true
-
-		case ranges.EOF:
-			if err := p.close(pos); err != nil {
-				return nil, err
-			}
-			break parse
-
-		case ranges.NUMBER:
-			p.addNumberToVersion(lit)
-			p.expectingNumber = false
-
-		case ranges.WILDCARD:
-			if p.max {
-				p.addNumberToVersion(maxUint64)
-			} else {
-				p.addNumberToVersion(0)
-			}
-			if p.semverPos != 0 {
-				p.semverPos--
-			}
-			p.expectingNumber = false
-
-		case ranges.DOT:
-			p.expectingNumber = true
-			p.semverPos++
-			if p.semverPos > 2 {
-				return nil, fmt.Errorf("%s: found 3rd dot when parsing semver", pos)
-			}
-		}
-	}
-	if p.c == nil {
-		return nil, fmt.Errorf("%s: empty", internal.Position(1))
-	}
-
-	return p.c, nil
+	perr.Input = string(src)
+	return append(l, perr)
 }
 
 // compactLogicalOR combines adjacent or overlapping ranges in OR operations.
@@ -388,11 +309,12 @@ func compactLogicalOR(constraints or) or {
 		current := ranges[i]
 
 		// Check if current range overlaps or is adjacent to the last merged range
-		// Adjacent means last.Max >= current.Min (allowing for touching at boundary)
-		if !last.Max.LessThan(current.Min) {
+		// Adjacent means there is no version in between,
+		// e.g. <1.2.0 and >=1.2.0 or 1.0.0 - 1.2.0 and 1.2.0 - 2.0.0.
+		if boundsConnect(last.upper(), current.lower()) {
 			// Merge: extend last range's max if current goes further
-			if current.Max.GreaterThan(last.Max) {
-				last.Max = current.Max
+			if compareUpper(current.upper(), last.upper()) > 0 {
+				last.setUpper(current.upper())
 			}
 		} else {
 			// No overlap, add as new range
@@ -421,23 +343,22 @@ func simplifyIntersectingRanges(ranges []Range) []Range {
 	// Calculate the intersection of all ranges
 	// Intersection min = max of all mins
 	// Intersection max = min of all maxs
-	intersectionMin := ranges[0].Min
-	intersectionMax := ranges[0].Max
+	intersection := ranges[0]
 
 	for i := 1; i < len(ranges); i++ {
 		// Update min to the highest min
-		if ranges[i].Min.GreaterThan(intersectionMin) {
-			intersectionMin = ranges[i].Min
+		if compareLower(ranges[i].lower(), intersection.lower()) > 0 {
+			intersection.setLower(ranges[i].lower())
 		}
 		// Update max to the lowest max
-		if ranges[i].Max.LessThan(intersectionMax) {
-			intersectionMax = ranges[i].Max
+		if compareUpper(ranges[i].upper(), intersection.upper()) < 0 {
+			intersection.setUpper(ranges[i].upper())
 		}
 	}
 
 	// If intersection is a single version, replace all ranges with that single version
-	if intersectionMin.Same(intersectionMax) {
-		return []Range{{Min: intersectionMin, Max: intersectionMax}}
+	if intersection.isSingleVersion() {
+		return []Range{intersection}
 	}
 
 	// Otherwise, return ranges as-is
@@ -465,52 +386,57 @@ func compactAndValidateLogicalAND(pos internal.Position, and and) (and, error) {
 	var newRanges []Range
 	var otherConstraints []Constraint
 
-	// find min version and max version
+	// find ranges providing the min version and max version
 	var (
-		maxVersion *Version
-		minVersion *Version
+		maxRange *Range
+		minRange *Range
 	)
 	for _, c := range and {
 		r, ok := c.(*Range)
 		switch {
 		case ok && isMinUnconstraint(*r):
-			if maxVersion != nil {
-				return nil, fmt.Errorf(
-					"%s: <=%s is redundant with <=%s in logical AND",
-					pos, r.Max.String(), maxVersion,
+			if maxRange != nil {
+				return nil, parseErrorf(
+					pos, ErrRedundantBound, "%s is redundant with %s in logical AND", r.upper(), maxRange.upper(),
 				)
 			}
-			maxVersion = &r.Max
+			maxRange = r
 
 		case ok && isMaxUnconstraint(*r):
-			if minVersion != nil {
-				return nil, fmt.Errorf(
-					"%s: >=%s is redundant with >=%s in logical AND",
-					pos, r.Min.String(), minVersion,
+			if minRange != nil {
+				return nil, parseErrorf(
+					pos, ErrRedundantBound, "%s is redundant with %s in logical AND", r.lower(), minRange.lower(),
 				)
 			}
-			minVersion = &r.Min
+			minRange = r
 
 		case ok:
 			// Don't combine full ranges in AND - they represent intersections, not unions.
 			// Only combine when we have separate lower/upper bounds (e.g., >=X && <=Y).
-			if minVersion != nil && maxVersion != nil {
-				// We already have a combined range, so this is a separate constraint
+			if minRange != nil || maxRange != nil {
+				// We already have a bound, so this is a separate constraint
 				newRanges = append(newRanges, *r)
 			} else {
-				minVersion = &r.Min
-				maxVersion = &r.Max
+				minRange = r
+				maxRange = r
 			}
 
 		default:
 			otherConstraints = append(otherConstraints, c)
 		}
 	}
-	if minVersion != nil && maxVersion != nil {
-		newRanges = append(newRanges, Range{
-			Min: *minVersion,
-			Max: *maxVersion,
-		})
+	switch {
+	case minRange != nil && maxRange != nil:
+		var r Range
+		r.setLower(minRange.lower())
+		r.setUpper(maxRange.upper())
+		newRanges = append(newRanges, r)
+	case minRange != nil:
+		// keep single lower bound, e.g. ">=X && !=Y"
+		newRanges = append(newRanges, *minRange)
+	case maxRange != nil:
+		// keep single upper bound, e.g. "<=X && !=Y"
+		newRanges = append(newRanges, *maxRange)
 	}
 
 	sort.Sort(AscendingMin(newRanges))
@@ -547,10 +473,9 @@ func validateNotOverConstrained(pos internal.Position, constraints and) error {
 		switch v := c.(type) {
 		case *Range:
 			// Check for impossible individual ranges (min > max)
-			if v.Min.GreaterThan(v.Max) {
-				return fmt.Errorf(
-					"%s: over-constrained, no version can satisfy %s (min > max)",
-					pos, v.String(),
+			if v.isEmpty() {
+				return parseErrorf(
+					pos, ErrOverConstrained, "over-constrained, no version can satisfy %s (min > max)", v.String(),
 				)
 			}
 			ranges = append(ranges, *v)
@@ -574,9 +499,9 @@ func validateNotOverConstrained(pos internal.Position, constraints and) error {
 		for i := range len(ranges) - 1 {
 			for j := i + 1; j < len(ranges); j++ {
 				if !rangesOverlap(ranges[i], ranges[j]) {
-					return fmt.Errorf(
-						"%s: over-constrained, ranges do not overlap: %s AND %s",
-						pos, ranges[i].String(), ranges[j].String(),
+					return parseErrorf(
+						pos, ErrOverConstrained, "over-constrained, ranges do not overlap: %s AND %s",
+						ranges[i].String(), ranges[j].String(),
 					)
 				}
 			}
@@ -585,30 +510,30 @@ func validateNotOverConstrained(pos internal.Position, constraints and) error {
 
 	// Check if we have both >= and <= constraints that don't overlap
 	// This catches cases like ">=2.0.0 && <1.0.0"
-	var minBound *Version // from >= constraint
-	var maxBound *Version // from <= or < constraint
+	var minBound *bound // from >= or > constraint
+	var maxBound *bound // from <= or < constraint
 
 	for _, r := range ranges {
 		// Check if this is a lower bound (>= or >)
 		if isMaxUnconstraint(r) {
-			if minBound == nil || r.Min.GreaterThan(*minBound) {
-				minBound = &r.Min
+			if lower := r.lower(); minBound == nil || compareLower(lower, *minBound) > 0 {
+				minBound = &lower
 			}
 		}
 		// Check if this is an upper bound (<= or <)
 		if isMinUnconstraint(r) {
-			if maxBound == nil || r.Max.LessThan(*maxBound) {
-				maxBound = &r.Max
+			if upper := r.upper(); maxBound == nil || compareUpper(upper, *maxBound) < 0 {
+				maxBound = &upper
 			}
 		}
 	}
 
 	// If we have both bounds, check if they're compatible
 	if minBound != nil && maxBound != nil {
-		if minBound.GreaterThan(*maxBound) {
-			return fmt.Errorf(
-				"%s: over-constrained, lower bound %s is greater than upper bound %s",
-				pos, minBound.String(), maxBound.String(),
+		if !boundsOverlap(*minBound, *maxBound) {
+			return parseErrorf(
+				pos, ErrOverConstrained, "over-constrained, lower bound %s is greater than upper bound %s",
+				minBound.v.String(), maxBound.v.String(),
 			)
 		}
 	}
@@ -617,13 +542,12 @@ func validateNotOverConstrained(pos internal.Position, constraints and) error {
 	if len(ranges) > 0 && len(notConstraints) > 0 {
 		// For single-version ranges with NOT, check if they exclude that exact version
 		for _, r := range ranges {
-			if r.Min.Same(r.Max) {
+			if r.isSingleVersion() {
 				// This is an equality constraint (e.g., =1.0.0)
 				for _, n := range notConstraints {
 					if n.Min.Same(r.Min) && n.Max.Same(r.Max) {
-						return fmt.Errorf(
-							"%s: over-constrained, %s AND %s excludes all versions",
-							pos, r.String(), n.String(),
+						return parseErrorf(
+							pos, ErrOverConstrained, "over-constrained, %s AND %s excludes all versions", r.String(), n.String(),
 						)
 					}
 				}
@@ -638,13 +562,13 @@ func validateNotOverConstrained(pos internal.Position, constraints and) error {
 func rangesOverlap(a, b Range) bool {
 	// Ranges overlap if:
 	// - a.Min <= b.Max AND b.Min <= a.Max
-	return !a.Min.GreaterThan(b.Max) && !b.Min.GreaterThan(a.Max)
+	return boundsOverlap(a.lower(), b.upper()) && boundsOverlap(b.lower(), a.upper())
 }
 
 func isMinUnconstraint(r Range) bool {
-	return r.Min.Same(Version{})
+	return r.lower().unbounded
 }
 
 func isMaxUnconstraint(r Range) bool {
-	return r.Max.Same(Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64})
+	return r.upper().unbounded
 }
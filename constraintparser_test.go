@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -87,12 +88,14 @@ func TestConstraintParser_success(t *testing.T) {
 				},
 				and{
 					&Range{
-						Min: Version{Major: 1, Minor: 2, Patch: 4},
-						Max: Version{Major: 2, Minor: 3, Patch: 4},
+						Min:          Version{Major: 1, Minor: 2, Patch: 3},
+						Max:          Version{Major: 5, Minor: 4, Patch: 0},
+						MinExclusive: true,
+						MaxExclusive: true,
 					},
 					&Range{
 						Min: Version{Major: 1, Minor: 2, Patch: 4},
-						Max: Version{Major: 5, Minor: 3, Patch: maxUint64},
+						Max: Version{Major: 2, Minor: 3, Patch: 4},
 					},
 				},
 				not{
@@ -145,128 +148,138 @@ func TestConstraintParser_success(t *testing.T) {
 			name:  "greater",
 			input: `>1.2.3`,
 			expected: &Range{
-				Min: Version{Major: 1, Minor: 2, Patch: 4},
-				Max: Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64},
+				Min:          Version{Major: 1, Minor: 2, Patch: 3},
+				MinExclusive: true,
+				MaxUnbounded: true,
 			},
 		},
 		{
 			name:  "greater minor",
 			input: `>1.2`,
 			expected: &Range{
-				Min: Version{Major: 1, Minor: 3, Patch: 0},
-				Max: Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64},
+				Min:          Version{Major: 1, Minor: 2, Patch: maxUint64},
+				MinExclusive: true,
+				MaxUnbounded: true,
 			},
 		},
 		{
 			name:  "greater minor wildcard",
 			input: `>1.2.x`,
 			expected: &Range{
-				Min: Version{Major: 1, Minor: 3, Patch: 0},
-				Max: Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64},
+				Min:          Version{Major: 1, Minor: 2, Patch: maxUint64},
+				MinExclusive: true,
+				MaxUnbounded: true,
 			},
 		},
 		{
 			name:  "greater major",
 			input: `>1`,
 			expected: &Range{
-				Min: Version{Major: 2, Minor: 0, Patch: 0},
-				Max: Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64},
+				Min:          Version{Major: 1, Minor: maxUint64, Patch: maxUint64},
+				MinExclusive: true,
+				MaxUnbounded: true,
 			},
 		},
 		{
 			name:  "greater major wildcard",
 			input: `>1.x`,
 			expected: &Range{
-				Min: Version{Major: 2, Minor: 0, Patch: 0},
-				Max: Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64},
+				Min:          Version{Major: 1, Minor: maxUint64, Patch: maxUint64},
+				MinExclusive: true,
+				MaxUnbounded: true,
 			},
 		},
 		{
 			name:  "greater equal",
 			input: `>=1.2.3`,
 			expected: &Range{
-				Min: Version{Major: 1, Minor: 2, Patch: 3},
-				Max: Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64},
+				Min:          Version{Major: 1, Minor: 2, Patch: 3},
+				MaxUnbounded: true,
 			},
 		},
 		{
 			name:  "greater equal major",
 			input: `>=1`,
 			expected: &Range{
-				Min: Version{Major: 1, Minor: 0, Patch: 0},
-				Max: Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64},
+				Min:          Version{Major: 1, Minor: 0, Patch: 0},
+				MaxUnbounded: true,
 			},
 		},
 		{
 			name:  "greater equal minor",
 			input: `>=1.41`,
 			expected: &Range{
-				Min: Version{Major: 1, Minor: 41, Patch: 0},
-				Max: Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64},
+				Min:          Version{Major: 1, Minor: 41, Patch: 0},
+				MaxUnbounded: true,
 			},
 		},
 		{
 			name:  "less",
 			input: `<1.2.3`,
 			expected: &Range{
-				Min: Version{Major: 0, Minor: 0, Patch: 0},
-				Max: Version{Major: 1, Minor: 2, Patch: 2},
+				MinUnbounded: true,
+				Max:          Version{Major: 1, Minor: 2, Patch: 3},
+				MaxExclusive: true,
 			},
 		},
 		{
 			name:  "less minor",
 			input: `<1.2`,
 			expected: &Range{
-				Min: Version{Major: 0, Minor: 0, Patch: 0},
-				Max: Version{Major: 1, Minor: 1, Patch: maxUint64},
+				MinUnbounded: true,
+				Max:          Version{Major: 1, Minor: 2, Patch: 0},
+				MaxExclusive: true,
 			},
 		},
 		{
 			name:  "less minor wildcard",
 			input: `<1.2.x`,
 			expected: &Range{
-				Min: Version{Major: 0, Minor: 0, Patch: 0},
-				Max: Version{Major: 1, Minor: 1, Patch: maxUint64},
+				MinUnbounded: true,
+				Max:          Version{Major: 1, Minor: 2, Patch: 0},
+				MaxExclusive: true,
 			},
 		},
 		{
 			name:  "less major",
 			input: `<1`,
 			expected: &Range{
-				Min: Version{Major: 0, Minor: 0, Patch: 0},
-				Max: Version{Major: 0, Minor: maxUint64, Patch: maxUint64},
+				MinUnbounded: true,
+				Max:          Version{Major: 1, Minor: 0, Patch: 0},
+				MaxExclusive: true,
 			},
 		},
 		{
 			name:  "less major wildcard",
 			input: `<1.x`,
 			expected: &Range{
-				Min: Version{Major: 0, Minor: 0, Patch: 0},
-				Max: Version{Major: 0, Minor: maxUint64, Patch: maxUint64},
+				MinUnbounded: true,
+				Max:          Version{Major: 1, Minor: 0, Patch: 0},
+				MaxExclusive: true,
 			},
 		},
 		{
 			name:  "less equal",
 			input: `<=1.2.3`,
 			expected: &Range{
-				Min: Version{Major: 0, Minor: 0, Patch: 0},
-				Max: Version{Major: 1, Minor: 2, Patch: 3},
+				MinUnbounded: true,
+				Max:          Version{Major: 1, Minor: 2, Patch: 3},
 			},
 		},
 		{
 			name:  "less equal minor",
 			input: `<=1.42`,
 			expected: &Range{
-				Min: Version{Major: 0, Minor: 0, Patch: 0},
-				Max: Version{Major: 1, Minor: 42, Patch: 0},
+				MinUnbounded: true,
+				Max:          Version{Major: 1, Minor: 42, Patch: 0},
 			},
 		},
 		{
 			name:  "less equal major",
 			input: `<=42`,
 			expected: &Range{
-				Min: Version{Major: 0, Minor: 0, Patch: 0},
-				Max: Version{Major: 42, Minor: 0, Patch: 0},
+				MinUnbounded: true,
+				Max:          Version{Major: 42, Minor: 0, Patch: 0},
 			},
 		},
 		{
@@ -377,8 +390,9 @@ func TestConstraintParser_success(t *testing.T) {
 			name:  "greater-equal less compaction",
 			input: `>=3.4,<3.5`,
 			expected: &Range{
-				Min: Version{Major: 3, Minor: 4, Patch: 0},
-				Max: Version{Major: 3, Minor: 4, Patch: maxUint64},
+				Min:          Version{Major: 3, Minor: 4, Patch: 0},
+				Max:          Version{Major: 3, Minor: 5, Patch: 0},
+				MaxExclusive: true,
 			},
 		},
 		{
@@ -443,6 +457,38 @@ func TestConstraintParser_success(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "lower bound with not equal",
+			input: `>=1 && !=1.2.3`,
+			expected: and{
+				&Range{
+					Min:          Version{Major: 1, Minor: 0, Patch: 0},
+					MaxUnbounded: true,
+				},
+				not{
+					Range{
+						Min: Version{Major: 1, Minor: 2, Patch: 3},
+						Max: Version{Major: 1, Minor: 2, Patch: 3},
+					},
+				},
+			},
+		},
+		{
+			name:  "upper bound with not equal",
+			input: `<=2 && !=1.2.3`,
+			expected: and{
+				&Range{
+					MinUnbounded: true,
+					Max:          Version{Major: 2, Minor: 0, Patch: 0},
+				},
+				not{
+					Range{
+						Min: Version{Major: 1, Minor: 2, Patch: 3},
+						Max: Version{Major: 1, Minor: 2, Patch: 3},
+					},
+				},
+			},
+		},
 		{
 			name:  "exact version match via bounds",
 			input: `>=2.5.0 && <=2.5.0`,
@@ -451,6 +497,55 @@ func TestConstraintParser_success(t *testing.T) {
 				Max: Version{Major: 2, Minor: 5, Patch: 0},
 			},
 		},
+		{
+			name:  "pre-release range",
+			input: `1.0.0-rc.0 - 1.0.0-rc.10`,
+			expected: &Range{
+				Min: Version{Major: 1, PreRelease: PreReleaseIdentifierList{
+					ToPreReleaseIdentifier("rc"), ToPreReleaseIdentifier("0"),
+				}},
+				Max: Version{Major: 1, PreRelease: PreReleaseIdentifierList{
+					ToPreReleaseIdentifier("rc"), ToPreReleaseIdentifier("10"),
+				}},
+			},
+		},
+		{
+			name:  "pre-release greater",
+			input: `>1.2.3-alpha.1`,
+			expected: &Range{
+				Min: Version{Major: 1, Minor: 2, Patch: 3, PreRelease: PreReleaseIdentifierList{
+					ToPreReleaseIdentifier("alpha"), ToPreReleaseIdentifier("1"),
+				}},
+				MinExclusive: true,
+				MaxUnbounded: true,
+			},
+		},
+		{
+			name:  "pre-release tilde",
+			input: `~1.2.3-beta`,
+			expected: &Range{
+				Min: Version{Major: 1, Minor: 2, Patch: 3, PreRelease: PreReleaseIdentifierList{
+					ToPreReleaseIdentifier("beta"),
+				}},
+				Max: Version{Major: 1, Minor: 2, Patch: maxUint64},
+			},
+		},
+		{
+			name:  "build metadata",
+			input: `=1.2.3-rc.1+build.5`,
+			expected: &Range{
+				Min: Version{
+					Major: 1, Minor: 2, Patch: 3,
+					PreRelease:    PreReleaseIdentifierList{ToPreReleaseIdentifier("rc"), ToPreReleaseIdentifier("1")},
+					BuildMetadata: []string{"build", "5"},
+				},
+				Max: Version{
+					Major: 1, Minor: 2, Patch: 3,
+					PreRelease:    PreReleaseIdentifierList{ToPreReleaseIdentifier("rc"), ToPreReleaseIdentifier("1")},
+					BuildMetadata: []string{"build", "5"},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -515,6 +610,18 @@ func TestConstraintParser_error(t *testing.T) {
 			input:       `1.2 -- 3`,
 			expectedErr: "col 6: double hyphen in range constraint",
 		},
+		{
+			input:       `=1.x.1-rc.1`,
+			expectedErr: "col 7: pre-release not allowed on wildcard version 1.x.1",
+		},
+		{
+			input:       `x.1.2-rc.1 - 2`,
+			expectedErr: "col 6: pre-release not allowed on wildcard version x.1.2",
+		},
+		{
+			input:       `=1.x.1+build.1`,
+			expectedErr: "col 7: build metadata not allowed on wildcard version 1.x.1",
+		},
 		{
 			input:       `= 1.2.  3`,
 			expectedErr: "col 7: semver clause incomplete",
@@ -525,15 +632,44 @@ func TestConstraintParser_error(t *testing.T) {
 		},
 		{
 			input:       `>=1.3 && <2 && <1`, // Over-constrained: >=1.3 and <1 don't overlap
-			expectedErr: "col 17: over-constrained, ranges do not overlap: 1.3.0 - 1.x.x AND 0.0.0 - 0.x.x",
+			expectedErr: "col 17: over-constrained, ranges do not overlap: >=1.3.0 <2.0.0 AND <1.0.0",
 		},
 		{
 			input:       `>=1.3 && <2 && >1.1`, // >=1.3 is redundant, because >1.1 includes >=1.3
-			expectedErr: "col 19: >=1.2.0 is redundant with >=1.3.0 in logical AND",
+			expectedErr: "col 19: >1.1.x is redundant with >=1.3.0 in logical AND",
 		},
 		{
+			input:       `1.2.3-rc..1`,
+			expectedErr: "col 10: pre release identifier empty",
+		},
+		{
+			input:       `>=1.2.3-rc.1.0_1`,
+			expectedErr: `col 14: invalid pre release identifier "0_1"`,
+		},
+		{
+			input:       `=1.2.3+b1.`,
+			expectedErr: "col 11: build identifier empty",
+		},
+		{
+			input:       `=1.2+a`,
+			expectedErr: "col 5: unexpected character U+002B '+'",
+		},
+		{
+			// reported at the stray version, not at the second hyphen.
 			input:       `2 - 3 1 - 2`,
-			expectedErr: `col 9: double hyphen in range constraint`,
+			expectedErr: `col 7: missing operator before version`,
+		},
+		{
+			input:       `2 - 3 1`,
+			expectedErr: `col 7: missing operator before version`,
+		},
+		{
+			input:       `2 - 3 1 2`,
+			expectedErr: `col 7: missing operator before version`,
+		},
+		{
+			input:       `1 - 2 - 3`,
+			expectedErr: `col 7: double hyphen in range constraint`,
 		},
 		{
 			input:       `2 - 3 && 5 - 6 && 1 - 2`, // Non-overlapping ranges after compaction
@@ -549,6 +685,82 @@ func TestConstraintParser_error(t *testing.T) {
 	}
 }
 
+// single bounds next to != used to be dropped, allowing every version but the excluded one.
+func TestConstraintParser_boundWithNotEqual(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		version  string
+		expected bool
+	}{
+		{input: ">=1 && !=1.2.3", version: "0.5.0", expected: false},
+		{input: ">=1 && !=1.2.3", version: "1.2.3", expected: false},
+		{input: ">=1 && !=1.2.3", version: "1.2.4", expected: true},
+		{input: "<=2 && !=1.2.3", version: "3.0.0", expected: false},
+		{input: "<=2 && !=1.2.3", version: "1.0.0", expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.input+" "+test.version, func(t *testing.T) {
+			t.Parallel()
+			c := MustNewConstraint(test.input)
+			assert.Equal(t, test.expected, c.Check(MustNewVersion(test.version)))
+		})
+	}
+}
+
+// >=0.0.0 is a lower bound and used to be read as second upper bound next to < or <=.
+func TestConstraintParser_zeroLowerBound(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		version  string
+		expected bool
+	}{
+		{input: ">=0.0.0 <2.0.0", version: "0.0.0", expected: true},
+		{input: ">=0.0.0 <2.0.0", version: "1.5.0", expected: true},
+		{input: ">=0.0.0 <2.0.0", version: "2.0.0", expected: false},
+		{input: ">=0.0.0 <2.0.0", version: "0.0.0-rc.1", expected: false},
+		{input: "<=2.0.0 >=0.0.0 !=1.0.0", version: "1.0.0", expected: false},
+		{input: "<=2.0.0 >=0.0.0 !=1.0.0", version: "2.0.0", expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.input+" "+test.version, func(t *testing.T) {
+			t.Parallel()
+			c, err := NewConstraint(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, c.Check(MustNewVersion(test.version)))
+		})
+	}
+}
+
+// > with partial versions is exclusive above the wildcard upper end.
+func TestConstraintParser_greaterPartial(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		version  string
+		expected bool
+	}{
+		{input: ">1.2", version: "1.2.9", expected: false},
+		{input: ">1.2", version: "1.3.0-rc.1", expected: true},
+		{input: ">1.2", version: "1.3.0", expected: true},
+		{input: ">1.2.3", version: "1.2.4-rc.1", expected: true},
+		{input: ">1", version: "1.99.0", expected: false},
+		{input: ">1", version: "2.0.0-rc.1", expected: true},
+		{input: ">18446744073709551615", version: "0.0.1", expected: false},
+		{input: ">18446744073709551615", version: "18446744073709551615.0.0", expected: false},
+		{input: ">1.18446744073709551615", version: "1.0.0", expected: false},
+		{input: ">1.18446744073709551615", version: "2.0.0", expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.input+" "+test.version, func(t *testing.T) {
+			t.Parallel()
+			c := MustNewConstraint(test.input)
+			assert.Equal(t, test.expected, c.Check(MustNewVersion(test.version)))
+		})
+	}
+}
+
 func TestConstraintParser_invalidBytes(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -572,3 +784,84 @@ func TestConstraintParser_invalidBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestConstraintParser_recoverErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input        string
+		expectedErrs []string
+	}{
+		{
+			input: `=1.2.3.4 || >=2 && <1 || 1 - 2 - 3 || ~1.2`,
+			expectedErrs: []string{
+				"col 7: found 3rd dot when parsing semver",
+				"col 23: over-constrained, ranges do not overlap: >=2.0.0 AND <1.0.0",
+				"col 32: double hyphen in range constraint",
+			},
+		},
+		{
+			input: `>=1.2.3-rc..1 && <2 || || =3`,
+			expectedErrs: []string{
+				"col 12: pre release identifier empty",
+				"col 24: OR empty range constraint",
+			},
+		},
+		{
+			input: `1 || = \n && >=1`,
+			expectedErrs: []string{
+				"col 3: range closed without operator",
+				"col 9: unexpected character U+006E 'n'",
+			},
+		},
+		{
+			input: `>=1.3 && <2 && <1 || >=1.3 && <2 && >1.1`,
+			expectedErrs: []string{
+				"col 19: over-constrained, ranges do not overlap: >=1.3.0 <2.0.0 AND <1.0.0",
+				"col 40: >1.1.x is redundant with >=1.3.0 in logical AND",
+			},
+		},
+		{
+			input: `>=18446744073709551616 || ~1 || <1.99999999999999999999`,
+			expectedErrs: []string{
+				"col 3: number 18446744073709551616 too large",
+				"col 36: number 99999999999999999999 too large",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewConstraint(test.input, RecoverErrors{})
+			require.Error(t, err)
+
+			var list ParseErrorList
+			require.ErrorAs(t, err, &list)
+			msgs := make([]string, len(list))
+			for i := range list {
+				msgs[i] = list[i].Error()
+				assert.Equal(t, test.input, list[i].Input)
+			}
+			assert.Equal(t, test.expectedErrs, msgs)
+			assert.Equal(t, strings.Join(test.expectedErrs, "\n"), err.Error())
+
+			// without recovery, only the first error is returned.
+			_, err = NewConstraint(test.input)
+			require.EqualError(t, err, test.expectedErrs[0])
+		})
+	}
+}
+
+func TestConstraintParser_recoverErrors_kinds(t *testing.T) {
+	t.Parallel()
+	_, err := NewConstraint(`=1.2.3.4 || >=2 && <1`, RecoverErrors{})
+	require.ErrorIs(t, err, ErrSyntax)
+	require.ErrorIs(t, err, ErrOverConstrained)
+	require.NotErrorIs(t, err, ErrRedundantBound)
+
+	_, err = NewConstraint(`>=18446744073709551616 || ~1`, RecoverErrors{})
+	require.ErrorIs(t, err, ErrOverflow)
+
+	c, err := NewConstraint(`>=1.2 && <2`, RecoverErrors{})
+	require.NoError(t, err)
+	assert.True(t, c.Check(MustNewVersion("1.5.0")))
+}
@@ -1,6 +1,16 @@
 package semver
 
-import "strings"
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPreReleasePolicy is returned when marshaling Constraints
+// parsed with a pre-release policy other than PreReleaseInclude.
+var ErrPreReleasePolicy = errors.New("pre-release policy not encodable")
 
 // Constraint interface is common to all version constraints.
 type Constraint interface {
@@ -16,6 +26,7 @@ type Constraint interface {
 type originalInputConstraint struct {
 	Constraint
 	original string
+	ast      *AST // syntax tree of the original input
 }
 
 var _ Constraint = (*originalInputConstraint)(nil)
@@ -24,6 +35,146 @@ func (oi *originalInputConstraint) String() string {
 	return oi.original
 }
 
+// Constraints wraps a parsed Constraint into a concrete type,
+// so it can be used as field in types that are (un)marshaled
+// from JSON, YAML or other text based formats.
+// Constraints are marshaled into their original input string.
+//
+// ConstraintOptions are not part of the string and text and JSON always decode
+// with the default PreReleaseInclude policy. Marshaling Constraints parsed with
+// another policy, e.g. PreReleaseExplicit, fails with ErrPreReleasePolicy
+// instead of silently dropping it.
+//
+// The zero value holds no constraint, does not allow any version
+// and is marshaled into an empty string.
+type Constraints struct {
+	c Constraint
+}
+
+var (
+	_ Constraint               = Constraints{}
+	_ encoding.TextMarshaler   = Constraints{}
+	_ encoding.TextUnmarshaler = (*Constraints)(nil)
+	_ json.Marshaler           = Constraints{}
+	_ json.Unmarshaler         = (*Constraints)(nil)
+)
+
+// MustNewConstraints parses the given string into Constraints or panics.
+func MustNewConstraints(data string, opts ...ConstraintOption) Constraints {
+	c, err := NewConstraints(data, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// NewConstraints parses the given string into Constraints.
+func NewConstraints(data string, opts ...ConstraintOption) (Constraints, error) {
+	c, err := NewConstraint(data, opts...)
+	if err != nil {
+		return Constraints{}, err
+	}
+	return Constraints{c: c}, nil
+}
+
+// ConstraintsOf wraps the given Constraint.
+func ConstraintsOf(c Constraint) Constraints {
+	if cs, ok := c.(Constraints); ok {
+		return cs
+	}
+	return Constraints{c: c}
+}
+
+// Constraint returns the wrapped Constraint or nil if empty.
+func (cs Constraints) Constraint() Constraint {
+	return cs.c
+}
+
+// IsZero returns true if no Constraint is set.
+func (cs Constraints) IsZero() bool {
+	return cs.c == nil
+}
+
+// Check if the version is allowed by the constraint or not.
+// Always false, if no Constraint is set.
+func (cs Constraints) Check(v Version) bool {
+	if cs.c == nil {
+		return false
+	}
+	return cs.c.Check(v)
+}
+
+// Contains checks if a range is contained within the constraint.
+// Always false, if no Constraint is set.
+func (cs Constraints) Contains(other Constraint) bool {
+	if cs.c == nil {
+		return false
+	}
+	if o, ok := other.(Constraints); ok {
+		if o.c == nil {
+			return false
+		}
+		other = o.c
+	}
+	return cs.c.Contains(other)
+}
+
+// String returns the original input string of the Constraint.
+func (cs Constraints) String() string {
+	if cs.c == nil {
+		return ""
+	}
+	return cs.c.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// Fails with ErrPreReleasePolicy, if the Constraint was not parsed with PreReleaseInclude.
+func (cs Constraints) MarshalText() ([]byte, error) {
+	if p := policyOf(cs.c); p != PreReleaseInclude {
+		return nil, fmt.Errorf("marshaling %s with pre-release policy %s: %w", cs.String(), p, ErrPreReleasePolicy)
+	}
+	return []byte(cs.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// An empty text resets Constraints to the zero value.
+// The text is parsed with default ConstraintOptions.
+func (cs *Constraints) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*cs = Constraints{}
+		return nil
+	}
+	c, err := NewConstraints(string(text))
+	if err != nil {
+		return err
+	}
+	*cs = c
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// Constraints are encoded as JSON string, see MarshalText.
+func (cs Constraints) MarshalJSON() ([]byte, error) {
+	text, err := cs.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Constraints are decoded from a JSON string, null is a no-op.
+func (cs *Constraints) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return cs.UnmarshalText([]byte(s))
+}
+
 // and is a list of Ranges that all have to pass.
 type and []Constraint
 
@@ -41,10 +192,7 @@ func (and and) Check(v Version) bool {
 //nolint:revive // Receiver name differs from type to avoid shadowing
 func (a and) Contains(other Constraint) bool {
 	// Unwrap originalInputConstraint if needed
-	otherUnwrapped := other
-	if oic, ok := other.(*originalInputConstraint); ok {
-		otherUnwrapped = oic.Constraint
-	}
+	otherUnwrapped := unwrapConstraint(other)
 
 	// Special case: when checking if (A && B) contains (C && D)
 	otherAnd, ok := otherUnwrapped.(and)
@@ -82,7 +230,7 @@ func (a and) coversConstraint(c Constraint) bool {
 	}
 	// Check if it's the same constraint (e.g., both have !=2)
 	for _, ourConstraint := range a {
-		if ourConstraint.String() == c.String() {
+		if Equal(ourConstraint, c) {
 			return true
 		}
 	}
@@ -114,9 +262,10 @@ func (or or) Check(v Version) bool {
 //nolint:revive // Receiver name differs from type to avoid shadowing
 func (o or) Contains(other Constraint) bool {
 	// Unwrap originalInputConstraint if needed
-	otherUnwrapped := other
-	if oic, ok := other.(*originalInputConstraint); ok {
-		otherUnwrapped = oic.Constraint
+	otherUnwrapped := unwrapConstraint(other)
+
+	if rs, ok := otherUnwrapped.(RangeSet); ok {
+		otherUnwrapped = rs.or()
 	}
 
 	// Special case: when checking if (A || B) contains (C || D),
@@ -171,6 +320,17 @@ func (n not) Contains(other Constraint) bool {
 	switch v := other.(type) {
 	case *originalInputConstraint:
 		return n.Contains(v.Constraint)
+	case *preReleaseConstraint:
+		return n.Contains(v.Constraint)
+	case Constraints:
+		return v.c != nil && n.Contains(v.c)
+	case RangeSet:
+		for _, r := range v {
+			if rangesOverlap(n.Range, r) {
+				return false
+			}
+		}
+		return true
 	case not:
 		return rangeContainsRange(v.Range, n.Range)
 	case *Range:
@@ -196,3 +356,23 @@ func (n not) Contains(other Constraint) bool {
 func (not not) String() string {
 	return "!" + not.Range.String()
 }
+
+// unwrapConstraint removes originalInputConstraint, preReleaseConstraint and Constraints wrappers.
+// Dropping preReleaseConstraint discards the pre-release policy, see RangeSet.
+func unwrapConstraint(c Constraint) Constraint {
+	for {
+		switch v := c.(type) {
+		case *originalInputConstraint:
+			c = v.Constraint
+		case *preReleaseConstraint:
+			c = v.Constraint
+		case Constraints:
+			if v.c == nil {
+				return c
+			}
+			c = v.c
+		default:
+			return c
+		}
+	}
+}
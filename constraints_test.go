@@ -1,12 +1,104 @@
 package semver
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func TestConstraints(t *testing.T) {
+	t.Parallel()
+	cs := MustNewConstraints("~1.2 || >=2")
+
+	assert.False(t, cs.IsZero())
+	assert.Equal(t, "~1.2 || >=2", cs.String())
+	assert.True(t, cs.Check(MustNewVersion("1.2.4")))
+	assert.False(t, cs.Check(MustNewVersion("1.3.0")))
+	assert.True(t, cs.Contains(MustNewConstraints("1.2.1 - 1.2.5")))
+	assert.False(t, cs.Contains(MustNewConstraints("1.2.1 - 1.3.5")))
+	assert.True(t, MustNewConstraint("1 - x").Contains(cs.Constraint()))
+	assert.True(t, MustNewConstraint(">=1").Contains(cs))
+	assert.True(t, MustNewConstraint("!=3").Contains(MustNewConstraints("1 - 2")))
+	assert.Equal(t, cs, ConstraintsOf(cs))
+}
+
+func TestConstraints_zero(t *testing.T) {
+	t.Parallel()
+	var cs Constraints
+
+	assert.True(t, cs.IsZero())
+	assert.Nil(t, cs.Constraint())
+	assert.Empty(t, cs.String())
+	assert.False(t, cs.Check(MustNewVersion("1.0.0")))
+	assert.False(t, cs.Contains(MustNewConstraint("=1")))
+	assert.False(t, MustNewConstraints("=1").Contains(cs))
+	assert.False(t, (&Range{}).Contains(cs))
+
+	text, err := cs.MarshalText()
+	require.NoError(t, err)
+	assert.Empty(t, text)
+}
+
+func TestConstraints_JSON(t *testing.T) {
+	t.Parallel()
+	type obj struct {
+		Constraint Constraints `json:"constraint"`
+	}
+
+	in := obj{Constraint: MustNewConstraints(">= 1.2, != 1.4.5")}
+	j, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"constraint":">= 1.2, != 1.4.5"}`, string(j))
+
+	var out obj
+	require.NoError(t, json.Unmarshal(j, &out))
+	assert.Equal(t, in.Constraint.String(), out.Constraint.String())
+	assert.False(t, out.Constraint.Check(MustNewVersion("1.4.5")))
+	assert.True(t, out.Constraint.Check(MustNewVersion("1.4.6")))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"constraint":null}`), &out))
+	assert.False(t, out.Constraint.IsZero())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"constraint":""}`), &out))
+	assert.True(t, out.Constraint.IsZero())
+
+	j, err = json.Marshal(obj{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"constraint":""}`, string(j))
+
+	err = json.Unmarshal([]byte(`{"constraint":"1.2 -- 3"}`), &out)
+	require.EqualError(t, err, "col 6: double hyphen in range constraint")
+}
+
+// the pre-release policy can not be encoded, so marshaling fails instead of dropping it.
+func TestConstraints_JSON_preReleasePolicy(t *testing.T) {
+	t.Parallel()
+	tests := []string{
+		">=1.2.0-rc.1",
+		">=1.2.0 !=1.2.3",
+		"=1.2.3-rc.1 || >=2.0.0",
+	}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			in := MustNewConstraints(input, PreReleaseExplicit)
+
+			_, err := json.Marshal(in)
+			require.ErrorIs(t, err, ErrPreReleasePolicy)
+			_, err = in.MarshalText()
+			require.ErrorIs(t, err, ErrPreReleasePolicy)
+			_, err = json.Marshal(ConstraintsOf(in.Constraint()))
+			require.ErrorIs(t, err, ErrPreReleasePolicy)
+
+			j, err := json.Marshal(MustNewConstraints(input))
+			require.NoError(t, err)
+			assert.JSONEq(t, `"`+input+`"`, string(j))
+		})
+	}
+}
+
 func TestAnd(t *testing.T) {
 	t.Parallel()
 	t.Run("all true", func(t *testing.T) {
@@ -0,0 +1,273 @@
+package semver
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"pkg.package-operator.run/semver/internal"
+)
+
+// ErrNotExpressible is returned when a constraint can not be translated between SemVer and a dialect,
+// e.g. a logical OR as Cargo requirement or a PEP 440 post-release.
+var ErrNotExpressible = errors.New("constraint not expressible")
+
+// dialect captures the differences in desugaring comparators between
+// node-semver and Cargo, which otherwise share their semantics.
+type dialect struct {
+	// preZero excludes pre-releases of exclusive upper bounds
+	// derived from partial versions, e.g. <1.2 := <1.2.0-0.
+	preZero bool
+	// bare is the operator of comparators without operator.
+	bare string
+	// vPrefix allows versions to be prefixed with v, e.g. v1.2.3.
+	vPrefix bool
+}
+
+var (
+	npmDialect   = dialect{preZero: true, bare: "=", vPrefix: true}
+	cargoDialect = dialect{bare: "^"}
+)
+
+// dialectToken is a part of a constraint in a dialect syntax.
+type dialectToken struct {
+	text   string
+	offset int // byte offset within the input
+}
+
+// cutOperator splits one of the given operators from the start of a comparator.
+func cutOperator(s string, operators []string) (op, rest string) {
+	for _, op := range operators {
+		if rest, ok := strings.CutPrefix(s, op); ok {
+			return op, rest
+		}
+	}
+	return "", s
+}
+
+// looseVersion is a version with optional minor and patch, e.g. 1.x or 1.2.3-rc.1.
+type looseVersion struct {
+	nums [3]uint64
+	n    int // number of segments before the first wildcard or missing segment
+	pre  PreReleaseIdentifierList
+	// wildcard is true, if a segment is x, X or *.
+	wildcard bool
+}
+
+// version returns the partial version with missing segments set to 0.
+func (p looseVersion) version() Version {
+	v := Version{Major: p.nums[0], Minor: p.nums[1], Patch: p.nums[2]}
+	if p.n == 3 {
+		v.PreRelease = p.pre
+	}
+	return v
+}
+
+// preReleases returns the version, if it opts into pre-releases.
+func (p looseVersion) preReleases() []Version {
+	if p.n < 3 || len(p.pre) == 0 {
+		return nil
+	}
+	return []Version{p.version()}
+}
+
+// parseLooseVersion parses a version with missing segments and wildcards,
+// e.g. 1.2 or 1.x.x.
+func (d dialect) parseLooseVersion(data string, tok dialectToken) (looseVersion, error) {
+	var p looseVersion
+	s := tok.text
+	if d.vPrefix {
+		s = strings.TrimPrefix(s, "=")
+		s = strings.TrimPrefix(s, "v")
+	}
+	if len(s) == 0 {
+		return p, errorAtOffset(data, tok.offset, ErrMissingSegment, "missing version in %q", tok.text)
+	}
+
+	core, build, hasBuild := strings.Cut(s, "+")
+	if hasBuild {
+		for part := range strings.SplitSeq(build, ".") {
+			if !isBuildIdentifier(part) {
+				return p, errorAtOffset(data, tok.offset, ErrInvalidIdentifier, "invalid build identifier %q", part)
+			}
+		}
+	}
+	core, pre, hasPre := strings.Cut(core, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return p, errorAtOffset(data, tok.offset, ErrSyntax, "too many segments in %q", tok.text)
+	}
+	for i, part := range parts {
+		switch {
+		case part == "x" || part == "X" || part == "*":
+			p.wildcard = true
+		case len(part) > 0 && isNumericIdentifier(part):
+			num, err := strconv.ParseUint(part, 10, 64)
+			if err != nil {
+				return p, errorAtOffset(data, tok.offset, ErrOverflow, "number %s too large", part)
+			}
+			if !p.wildcard {
+				p.nums[i] = num
+				p.n++
+			}
+		default:
+			return p, errorAtOffset(data, tok.offset, ErrSyntax, "invalid version %q", tok.text)
+		}
+	}
+
+	if hasPre {
+		if len(parts) < 3 {
+			return p, errorAtOffset(data, tok.offset, ErrMissingSegment, "pre-release without patch in %q", tok.text)
+		}
+		for part := range strings.SplitSeq(pre, ".") {
+			if !isPreReleaseIdentifier(part) {
+				return p, errorAtOffset(data, tok.offset, ErrInvalidIdentifier, "invalid pre release identifier %q", part)
+			}
+			p.pre = append(p.pre, ToPreReleaseIdentifier(part))
+		}
+	}
+	return p, nil
+}
+
+// next returns the version after incrementing the segment at index i,
+// e.g. 1.3.0 for minor of 1.2.3.
+// Excluding the pre-releases of the result is up to the dialect.
+func (d dialect) next(data string, tok dialectToken, p looseVersion, i int) (Version, error) {
+	if p.nums[i] == maxUint64 {
+		return Version{}, errorAtOffset(data, tok.offset, ErrOverflow, "number %d too large", p.nums[i])
+	}
+	var nums [3]uint64
+	copy(nums[:i], p.nums[:i])
+	nums[i] = p.nums[i] + 1
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+// upper returns an exclusive upper bound derived from a partial version.
+func (d dialect) upper(data string, tok dialectToken, p looseVersion, i int) (Version, error) {
+	v, err := d.next(data, tok, p, i)
+	if err == nil && d.preZero {
+		v.PreRelease = PreReleaseIdentifierList{PreReleaseIdentifier{}}
+	}
+	return v, err
+}
+
+// comparatorRange desugars a single comparator into a Range.
+func (d dialect) comparatorRange(data, op string, p looseVersion, tok dialectToken) (Range, error) {
+	var r Range
+	v := p.version()
+	if len(op) == 0 {
+		op = d.bare
+	}
+
+	switch op {
+	case "~", "~>":
+		// ~1.2.3 := >=1.2.3 <1.3.0, ~1 := >=1.0.0 <2.0.0
+		if p.n == 0 {
+			return fullRange, nil
+		}
+		i := 1
+		if p.n == 1 {
+			i = 0
+		}
+		upper, err := d.upper(data, tok, p, i)
+		if err != nil {
+			return r, err
+		}
+		r.Min, r.Max, r.MaxExclusive = v, upper, true
+
+	case "^":
+		// ^1.2.3 := >=1.2.3 <2.0.0, ^0.2.3 := >=0.2.3 <0.3.0, ^0.0.3 := >=0.0.3 <0.0.4
+		if p.n == 0 {
+			return fullRange, nil
+		}
+		// first non-zero segment, or the last given one.
+		i := 0
+		for i < p.n-1 && p.nums[i] == 0 {
+			i++
+		}
+		upper, err := d.upper(data, tok, p, i)
+		if err != nil {
+			return r, err
+		}
+		r.Min, r.Max, r.MaxExclusive = v, upper, true
+
+	case ">":
+		switch p.n {
+		case 0:
+			// >* matches nothing.
+			return Range{Min: Version{Major: 1}, Max: Version{}}, nil
+		case 3:
+			r.Min, r.MinExclusive = v, true
+		default:
+			// >1.2 := >=1.3.0
+			lower, err := d.next(data, tok, p, p.n-1)
+			if err != nil {
+				return r, err
+			}
+			r.Min = lower
+		}
+		r.MaxUnbounded = true
+
+	case ">=":
+		r.Min, r.MaxUnbounded = v, true
+
+	case "<":
+		if p.n < 3 && d.preZero {
+			// <1.2 := <1.2.0-0
+			v.PreRelease = PreReleaseIdentifierList{PreReleaseIdentifier{}}
+		}
+		r.Max, r.MaxExclusive, r.MinUnbounded = v, true, true
+
+	case "<=":
+		r.MinUnbounded = true
+		switch p.n {
+		case 0:
+			r.MaxUnbounded = true
+		case 3:
+			r.Max = v
+		default:
+			// <=1.2 := <1.3.0
+			upper, err := d.upper(data, tok, p, p.n-1)
+			if err != nil {
+				return r, err
+			}
+			r.Max, r.MaxExclusive = upper, true
+		}
+
+	default:
+		// =1.2 := >=1.2.0 <1.3.0
+		switch p.n {
+		case 0:
+			return fullRange, nil
+		case 3:
+			r.Min, r.Max = v, v
+		default:
+			upper, err := d.upper(data, tok, p, p.n-1)
+			if err != nil {
+				return r, err
+			}
+			r.Min, r.Max, r.MaxExclusive = v, upper, true
+		}
+	}
+	return r, nil
+}
+
+// narrow intersects the Range with another Range.
+func (r *Range) narrow(o Range) {
+	if compareLower(o.lower(), r.lower()) > 0 {
+		r.setLower(o.lower())
+	}
+	if compareUpper(o.upper(), r.upper()) < 0 {
+		r.setUpper(o.upper())
+	}
+}
+
+// errorAtOffset creates a ParseError at the given byte offset of data.
+func errorAtOffset(data string, offset int, kind error, format string, args ...any) *ParseError {
+	col := utf8.RuneCountInString(data[:offset]) + 1
+	err := parseErrorf(internal.Position(col), kind, format, args...)
+	err.Input = data
+	return err
+}
@@ -0,0 +1,120 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"pkg.package-operator.run/semver/internal"
+)
+
+// Kinds of ParseErrors, use errors.Is to check for a kind.
+var (
+	// ErrEmptyInput is returned when parsing empty input.
+	ErrEmptyInput = errors.New("empty input")
+	// ErrMissingSegment is returned when a part of a version is missing, e.g. the patch in 1.2.
+	ErrMissingSegment = errors.New("missing segment")
+	// ErrInvalidCharacter is returned for characters that are not allowed at their position.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrInvalidIdentifier is returned for invalid pre-release or build identifiers.
+	ErrInvalidIdentifier = errors.New("invalid identifier")
+	// ErrSyntax is returned for malformed constraints, e.g. 1 - 2 - 3.
+	ErrSyntax = errors.New("syntax error")
+	// ErrOverConstrained is returned for constraints that no version can satisfy.
+	ErrOverConstrained = errors.New("over-constrained")
+	// ErrRedundantBound is returned for bounds that are made redundant by another bound in a logical AND.
+	ErrRedundantBound = errors.New("redundant bound")
+	// ErrOverflow is returned for numbers exceeding uint64 and, outside of ParseErrors,
+	// when a version number segment can not be incremented, because it already holds the max uint64 value.
+	ErrOverflow = errors.New("version segment overflow")
+)
+
+// ParseError is returned when parsing a Version or Constraint fails.
+type ParseError struct {
+	// Input that failed to parse.
+	Input string
+	// Pos is the character column the issue was encountered on, starting at 1.
+	Pos int
+	// Kind of error, one of the Err* sentinels of this package.
+	Kind error
+	// Msg describes the issue.
+	Msg string
+}
+
+// Error returns the column and message, e.g. col 4: missing patch.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %s", internal.Position(e.Pos), e.Msg)
+}
+
+// Unwrap returns the Kind of error.
+func (e *ParseError) Unwrap() error {
+	return e.Kind
+}
+
+// Snippet returns the input with a caret under the failing column:
+//
+//	>=1.3 && <2 && <1
+//	                ^
+func (e *ParseError) Snippet() string {
+	var b strings.Builder
+	b.WriteString(e.Input)
+	b.WriteByte('\n')
+	col := 1
+	for _, r := range e.Input {
+		if col >= e.Pos {
+			break
+		}
+		// keep tabs to align the caret
+		if r == '\t' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte(' ')
+		}
+		col++
+	}
+	for ; col < e.Pos; col++ {
+		b.WriteByte(' ')
+	}
+	b.WriteByte('^')
+	return b.String()
+}
+
+// ParseErrorList is returned when parsing a Constraint with RecoverErrors.
+// It contains every ParseError in order of occurrence.
+type ParseErrorList []*ParseError
+
+// Error returns all errors, one per line.
+func (l ParseErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, e := range l {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns all ParseErrors for use with errors.Is and errors.As.
+func (l ParseErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, e := range l {
+		errs[i] = e
+	}
+	return errs
+}
+
+// parseErrorf creates a new ParseError, the Input is set by the caller.
+func parseErrorf(pos internal.Position, kind error, format string, args ...any) *ParseError {
+	return &ParseError{
+		Pos:  int(pos),
+		Kind: kind,
+		Msg:  fmt.Sprintf(format, args...),
+	}
+}
+
+// withInput sets the input on ParseErrors.
+func withInput(err error, src []byte) error {
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		perr.Input = string(src)
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseError_Version(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		kind  error
+		pos   int
+	}{
+		{input: "", kind: ErrEmptyInput, pos: 1},
+		{input: "1.2", kind: ErrMissingSegment, pos: 4},
+		{input: "1..", kind: ErrMissingSegment, pos: 3},
+		{input: "1.2 .", kind: ErrInvalidCharacter, pos: 4},
+		{input: "alpha", kind: ErrInvalidCharacter, pos: 1},
+		{input: "1.0.0-alpha_beta", kind: ErrInvalidIdentifier, pos: 7},
+		{input: "1.1.2+.123", kind: ErrInvalidIdentifier, pos: 7},
+		{input: "1.99999999999999999999.0", kind: ErrOverflow, pos: 3},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewVersion(test.input)
+			require.ErrorIs(t, err, test.kind)
+
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, test.input, perr.Input)
+			assert.Equal(t, test.pos, perr.Pos)
+		})
+	}
+}
+
+func TestParseError_Constraint(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		kind  error
+		pos   int
+	}{
+		{input: "", kind: ErrEmptyInput, pos: 1},
+		{input: "= 1.2.  3", kind: ErrMissingSegment, pos: 7},
+		{input: "= \\n", kind: ErrInvalidCharacter, pos: 4},
+		{input: ">=1.2.3-rc..1", kind: ErrInvalidIdentifier, pos: 12},
+		{input: "2 - 3 1 - 2", kind: ErrSyntax, pos: 7},
+		{input: "1 - 2 - 3", kind: ErrSyntax, pos: 7},
+		{input: "=1.2.3.4", kind: ErrSyntax, pos: 7},
+		{input: ">=1.3 && <2 && <1", kind: ErrOverConstrained, pos: 17},
+		{input: ">=1.3 && <2 && >1.1", kind: ErrRedundantBound, pos: 19},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewConstraint(test.input)
+			require.ErrorIs(t, err, test.kind)
+
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, test.input, perr.Input)
+			assert.Equal(t, test.pos, perr.Pos)
+		})
+	}
+}
+
+func TestParseError_Snippet(t *testing.T) {
+	t.Parallel()
+	_, err := NewConstraint(">=1.3 && <2 && <1")
+	var perr *ParseError
+	require.True(t, errors.As(err, &perr))
+	assert.Equal(t, ">=1.3 && <2 && <1\n                ^", perr.Snippet())
+
+	_, err = NewVersion("1.2")
+	require.True(t, errors.As(err, &perr))
+	assert.Equal(t, "1.2\n   ^", perr.Snippet())
+
+	perr = &ParseError{Input: "\t1.x", Pos: 3}
+	assert.Equal(t, "\t1.x\n\t ^", perr.Snippet())
+}
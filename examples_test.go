@@ -58,6 +58,14 @@ func ExampleNewConstraint_range() {
 	// 2.0.0 - 3.0.0 is contained in range "1.0.0 - 2.0.0": false
 }
 
+func ExampleRecoverErrors() {
+	_, err := semver.NewConstraint("=1.2.3.4 || >=2 && <1", semver.RecoverErrors{})
+	fmt.Println(err)
+	// Output:
+	// col 7: found 3rd dot when parsing semver
+	// col 21: over-constrained, ranges do not overlap: >=2.0.0 AND <1.0.0
+}
+
 func ExampleAscending() {
 	versions := []semver.Version{
 		semver.MustNewVersion("1.2.4"),
@@ -0,0 +1,256 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explanation is a trace of why a Version does or does not satisfy a Constraint.
+type Explanation struct {
+	// Version that was checked.
+	Version Version
+	// Constraint the version was checked against, as written.
+	Constraint string
+	// Allowed is the result of Constraint.Check.
+	Allowed bool
+	// PreReleaseRejected is true, if the PreReleaseExplicit policy rejected the version,
+	// because no bound opts into pre-releases of its major.minor.patch.
+	PreReleaseRejected bool
+	// Branches that were tried in order, up to the first branch allowing the version.
+	Branches []BranchTrace
+}
+
+// BranchTrace explains a single || branch of a constraint.
+type BranchTrace struct {
+	// Branch as written, e.g. >=1.2 <2.
+	Branch string
+	// Span of the branch within the constraint, if it was parsed from a string.
+	Span Span
+	// Allowed is true, if all clauses of the branch allow the version.
+	Allowed bool
+	// PreReleaseRejected is true, if the PreReleaseExplicit policy rejected the version,
+	// because no bound of the branch opts into pre-releases of its major.minor.patch.
+	PreReleaseRejected bool
+	// Clauses that were tried in order, up to the first clause rejecting the version.
+	Clauses []ClauseTrace
+}
+
+// ClauseTrace explains a single clause of a || branch.
+type ClauseTrace struct {
+	// Clause as written, e.g. ~1.2.
+	Clause string
+	// Span of the clause within the constraint, if it was parsed from a string.
+	Span Span
+	// Allowed is true, if the clause allows the version.
+	Allowed bool
+	// Violated is the bound rejecting the version, e.g. <=1.2.x.
+	// Excluding clauses are reported as written, e.g. !=1.5.0.
+	Violated string
+}
+
+// Explain checks the version against the constraint and returns a trace of the result.
+// Constraints created by NewConstraint are explained in their original syntax,
+// other constraints in terms of their ranges.
+func Explain(c Constraint, v Version) Explanation {
+	e := Explanation{
+		Version:    v,
+		Constraint: c.String(),
+		Allowed:    c.Check(v),
+	}
+
+	var ast *AST
+	for done := false; !done; {
+		switch w := c.(type) {
+		case Constraints:
+			if w.c == nil {
+				return e
+			}
+			c = w.c
+		case *originalInputConstraint:
+			ast = w.ast
+			c = w.Constraint
+		case *preReleaseConstraint:
+			if preReleaseRejected(w, v) {
+				e.PreReleaseRejected = true
+				return e
+			}
+			c = w.Constraint
+		default:
+			done = true
+		}
+	}
+
+	if ast != nil {
+		e.Branches = explainAST(ast, c, v)
+	} else {
+		e.Branches = explainConstraint(c, v)
+	}
+	return e
+}
+
+// preReleaseRejected returns true, if the branch is lowered with the PreReleaseExplicit policy
+// and none of its bounds opts into pre-releases of the version.
+func preReleaseRejected(branch Constraint, v Version) bool {
+	w, ok := branch.(*preReleaseConstraint)
+	return ok && len(v.PreRelease) > 0 && !w.optsIn(v)
+}
+
+// String renders the explanation, one line per branch:
+//
+//	1.4.0 does not satisfy ~1.2 || >=2
+//	  ~1.2 rejects 1.4.0: not <=1.2.x
+//	  >=2 rejects 1.4.0: not >=2.0.0
+func (e Explanation) String() string {
+	var b strings.Builder
+	if e.Allowed {
+		fmt.Fprintf(&b, "%s satisfies %s", e.Version.String(), e.Constraint)
+	} else {
+		fmt.Fprintf(&b, "%s does not satisfy %s", e.Version.String(), e.Constraint)
+	}
+	if e.PreReleaseRejected {
+		release := e.Version.Release()
+		fmt.Fprintf(&b, ": no bound allows pre-releases of %s", release.String())
+	}
+
+	for _, branch := range e.Branches {
+		if branch.PreReleaseRejected {
+			release := e.Version.Release()
+			fmt.Fprintf(&b, "\n  %s: no bound allows pre-releases of %s", branch.Branch, release.String())
+			continue
+		}
+		if len(branch.Clauses) == 0 {
+			continue
+		}
+		b.WriteString("\n  ")
+		last := branch.Clauses[len(branch.Clauses)-1]
+		if last.Clause != branch.Branch {
+			b.WriteString(branch.Branch + ": ")
+		}
+		switch {
+		case last.Allowed:
+			fmt.Fprintf(&b, "%s allows %s", last.Clause, e.Version.String())
+		case last.Violated == last.Clause:
+			fmt.Fprintf(&b, "%s rejects %s", last.Clause, e.Version.String())
+		default:
+			fmt.Fprintf(&b, "%s rejects %s: not %s", last.Clause, e.Version.String(), last.Violated)
+		}
+	}
+	return b.String()
+}
+
+// explainAST traces the version through the clauses as written.
+// The lowered constraint holds the pre-release policy of each branch.
+func explainAST(ast *AST, lowered Constraint, v Version) []BranchTrace {
+	loweredBranches, ok := lowered.(or)
+	if !ok {
+		loweredBranches = or{lowered}
+	}
+
+	text := func(span Span, fallback string) string {
+		if span.End > len(ast.Input) || span.Len() == 0 {
+			return fallback
+		}
+		return ast.Input[span.Start:span.End]
+	}
+
+	var branches []BranchTrace
+	for i := range ast.Or {
+		g := &ast.Or[i]
+		branch := BranchTrace{
+			Branch:  text(g.Span, g.String()),
+			Span:    g.Span,
+			Allowed: true,
+		}
+		// branches are only lowered one by one with the PreReleaseExplicit policy.
+		if i < len(loweredBranches) && preReleaseRejected(loweredBranches[i], v) {
+			branch.Allowed, branch.PreReleaseRejected = false, true
+			branches = append(branches, branch)
+			continue
+		}
+		for j := range g.Clauses {
+			clause := &g.Clauses[j]
+			trace := explainClause(lowerClause(clause), text(clause.Span, clause.String()), v)
+			trace.Span = clause.Span
+			branch.Clauses = append(branch.Clauses, trace)
+			if !trace.Allowed {
+				branch.Allowed = false
+				break
+			}
+		}
+		branches = append(branches, branch)
+		if branch.Allowed {
+			break
+		}
+	}
+	return branches
+}
+
+// explainConstraint traces the version through the ranges of a constraint.
+func explainConstraint(c Constraint, v Version) []BranchTrace {
+	var o or
+	switch w := c.(type) {
+	case or:
+		o = w
+	case RangeSet:
+		o = w.or()
+	default:
+		o = or{c}
+	}
+
+	var branches []BranchTrace
+	for _, bc := range o {
+		branch := BranchTrace{
+			Branch:  bc.String(),
+			Allowed: true,
+		}
+		if w, ok := bc.(*preReleaseConstraint); ok {
+			if preReleaseRejected(w, v) {
+				branch.Allowed, branch.PreReleaseRejected = false, true
+				branches = append(branches, branch)
+				continue
+			}
+			bc = w.Constraint
+		}
+		a, ok := bc.(and)
+		if !ok {
+			a = and{bc}
+		}
+		for _, ac := range a {
+			trace := explainClause(ac, ac.String(), v)
+			branch.Clauses = append(branch.Clauses, trace)
+			if !trace.Allowed {
+				branch.Allowed = false
+				break
+			}
+		}
+		branches = append(branches, branch)
+		if branch.Allowed {
+			break
+		}
+	}
+	return branches
+}
+
+// explainClause checks the version against a single clause.
+func explainClause(c Constraint, clause string, v Version) ClauseTrace {
+	trace := ClauseTrace{
+		Clause:  clause,
+		Allowed: c.Check(v),
+	}
+	if trace.Allowed {
+		return trace
+	}
+
+	switch w := c.(type) {
+	case *Range:
+		if lower := w.lower(); !lower.allows(v) {
+			trace.Violated = lower.String()
+		} else {
+			trace.Violated = w.upper().String()
+		}
+	default:
+		// not and nested constraints are reported as written.
+		trace.Violated = clause
+	}
+	return trace
+}
@@ -0,0 +1,103 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		constraint Constraint
+		version    string
+		expected   string
+	}{
+		{
+			name:       "rejected by all branches",
+			constraint: MustNewConstraint("~1.2 || >=2"),
+			version:    "1.4.0",
+			expected: "1.4.0 does not satisfy ~1.2 || >=2\n" +
+				"  ~1.2 rejects 1.4.0: not <=1.2.x\n" +
+				"  >=2 rejects 1.4.0: not >=2.0.0",
+		},
+		{
+			name:       "allowed by second branch",
+			constraint: MustNewConstraint("~1.2 || ~1.4"),
+			version:    "1.4.0",
+			expected: "1.4.0 satisfies ~1.2 || ~1.4\n" +
+				"  ~1.2 rejects 1.4.0: not <=1.2.x\n" +
+				"  ~1.4 allows 1.4.0",
+		},
+		{
+			name:       "excluded",
+			constraint: MustNewConstraint(">=1 <2 != 1.4.0"),
+			version:    "1.4.0",
+			expected: "1.4.0 does not satisfy >=1 <2 != 1.4.0\n" +
+				"  >=1 <2 != 1.4.0: != 1.4.0 rejects 1.4.0",
+		},
+		{
+			name:       "pre-release policy",
+			constraint: MustNewConstraint(">=1.2.0-rc.1", PreReleaseExplicit),
+			version:    "1.3.0-rc.1",
+			expected:   "1.3.0-rc.1 does not satisfy >=1.2.0-rc.1: no bound allows pre-releases of 1.3.0",
+		},
+		{
+			name:       "pre-release policy per branch",
+			constraint: MustNewConstraint("=1.2.3-rc.1 || >=1.0.0", PreReleaseExplicit),
+			version:    "1.2.3-rc.5",
+			expected: "1.2.3-rc.5 does not satisfy =1.2.3-rc.1 || >=1.0.0\n" +
+				"  =1.2.3-rc.1 rejects 1.2.3-rc.5: not <=1.2.3-rc.1\n" +
+				"  >=1.0.0: no bound allows pre-releases of 1.2.3",
+		},
+		{
+			name:       "range",
+			constraint: &Range{Min: Version{Major: 1}, Max: Version{Major: 1, Minor: 2}},
+			version:    "1.4.0",
+			expected: "1.4.0 does not satisfy 1.0.0 - 1.2.0\n" +
+				"  1.0.0 - 1.2.0 rejects 1.4.0: not <=1.2.0",
+		},
+		{
+			name:       "zero Constraints",
+			constraint: Constraints{},
+			version:    "1.4.0",
+			expected:   "1.4.0 does not satisfy ",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			v := MustNewVersion(test.version)
+			e := Explain(test.constraint, v)
+			assert.Equal(t, test.constraint.Check(v), e.Allowed)
+			assert.Equal(t, test.expected, e.String())
+		})
+	}
+}
+
+func TestExplain_trace(t *testing.T) {
+	t.Parallel()
+	e := Explain(MustNewConstraint(">=1.2 <1.4 || 2.x - 3"), MustNewVersion("1.5.0"))
+	assert.Equal(t, Explanation{
+		Version:    MustNewVersion("1.5.0"),
+		Constraint: ">=1.2 <1.4 || 2.x - 3",
+		Branches: []BranchTrace{
+			{
+				Branch: ">=1.2 <1.4",
+				Span:   Span{Start: 0, End: 10},
+				Clauses: []ClauseTrace{
+					{Clause: ">=1.2", Span: Span{Start: 0, End: 5}, Allowed: true},
+					{Clause: "<1.4", Span: Span{Start: 6, End: 10}, Violated: "<1.4.0"},
+				},
+			},
+			{
+				Branch: "2.x - 3",
+				Span:   Span{Start: 14, End: 21},
+				Clauses: []ClauseTrace{
+					{Clause: "2.x - 3", Span: Span{Start: 14, End: 21}, Violated: ">=2.0.0"},
+				},
+			},
+		},
+	}, e)
+}
@@ -0,0 +1,375 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatStyle selects the syntax Format prints a Constraint in.
+type FormatStyle int
+
+const (
+	// StyleNative is the syntax of NewConstraint, e.g. ^1.2.3 !=1.5.0 || >=2.1.0.
+	StyleNative FormatStyle = iota
+	// StyleNPM is the range syntax of npm, see ParseNPM, e.g. ^1.2.3 || >=2.1.0.
+	StyleNPM
+	// StyleCargo is the version requirement syntax of Cargo, see ParseCargo, e.g. 1.2.3 or >=1.2.0, <1.5.0.
+	StyleCargo
+	// StylePEP440 is the version specifier syntax of Python, see ParsePEP440, e.g. ~=1.2.3, !=1.2.5.
+	StylePEP440
+	// StyleInterval is the interval notation of Maven and NuGet, see ParseInterval, e.g. [1.2.3,2.0.0),[2.1.0,).
+	StyleInterval
+)
+
+func (s FormatStyle) String() string {
+	switch s {
+	case StyleNative:
+		return "Native"
+	case StyleNPM:
+		return "NPM"
+	case StyleCargo:
+		return "Cargo"
+	case StylePEP440:
+		return "PEP440"
+	case StyleInterval:
+		return "Interval"
+	}
+	return "Unknown"
+}
+
+// Format prints the versions allowed by the Constraint in the syntax of the given style,
+// so generated manifests can be read by other tools.
+// Ranges are printed as caret and tilde shorthand, where the shorthand matches exactly,
+// and as comparators otherwise.
+//
+// Constraints that allow no version, or that need syntax the style is lacking,
+// e.g. a logical OR as Cargo requirement, return ErrNotExpressible.
+// So do bounds with the <max> number 18446744073709551615 reserved for wildcards,
+// as they would be read back as wildcard.
+// Inclusive wildcard upper bounds are printed as exclusive bound of the next version,
+// e.g. <=1.2.x as <1.3.0-0, or as <1.3.0 for styles that only match pre-releases explicitly.
+func Format(c Constraint, style FormatStyle) (string, error) {
+	f, ok := formatters[style]
+	if !ok {
+		return "", fmt.Errorf("unknown format style %d", style)
+	}
+
+	var rs RangeSet
+	for _, r := range Normalize(c) {
+		r = f.canonical(r)
+		if r.isEmpty() {
+			// ranges only allowing pre-releases become empty without them,
+			// e.g. >=1.0.0-0 <1.0.0 in releaseOnly styles.
+			continue
+		}
+		if hasMaxNumber(r) {
+			return "", fmt.Errorf("%w in %s syntax: %s has a bound with the <max> number", ErrNotExpressible, style, c.String())
+		}
+		rs = append(rs, r)
+	}
+	switch {
+	case len(rs) == 0:
+		return "", fmt.Errorf("%w in %s syntax: %s allows no version", ErrNotExpressible, style, c.String())
+
+	case style == StyleInterval:
+		intervals := make([]string, len(rs))
+		for i := range rs {
+			intervals[i] = rs[i].Interval()
+		}
+		return strings.Join(intervals, ","), nil
+
+	case len(rs) == 1:
+		return f.formatRange(rs[0])
+	}
+
+	if f.exclusions {
+		if s, ok, err := f.formatExclusions(rs); ok || err != nil {
+			return s, err
+		}
+	}
+	if len(f.or) == 0 {
+		return "", fmt.Errorf("%w in %s syntax: %s has multiple ranges", ErrNotExpressible, style, c.String())
+	}
+	branches := make([]string, len(rs))
+	for i := range rs {
+		s, err := f.formatRange(rs[i])
+		if err != nil {
+			return "", err
+		}
+		branches[i] = s
+	}
+	return strings.Join(branches, f.or), nil
+}
+
+// formatter describes the syntax of a FormatStyle.
+type formatter struct {
+	parse func(string) (Constraint, error)
+	// and and or separate comparators and ranges, an empty or is not supported.
+	and, or string
+	// any matches every version.
+	any string
+	// exact is the operator of a single version.
+	exact string
+	// exclusions allow gaps of single versions between ranges to be written as != clauses.
+	exclusions bool
+	// prefixExclusions allow gaps between ranges to be written as != prefix clauses, e.g. !=1.2.*.
+	prefixExclusions bool
+	// releaseOnly styles only match pre-releases explicitly,
+	// so bounds of the lowest pre-release -0 are written without pre-release.
+	releaseOnly bool
+	// shorthands returns the candidates to write min <= v < max in,
+	// given the forms of min returned by versionForms.
+	shorthands func(forms []string) []string
+	// version prints a version.
+	version func(v Version) (string, error)
+}
+
+var formatters = map[FormatStyle]formatter{
+	StyleNative: {
+		parse: func(s string) (Constraint, error) { return NewConstraint(s) },
+		and:   " ", or: " || ", any: ">=0.0.0-0", exact: "=", exclusions: true,
+		shorthands: func(forms []string) []string {
+			return append(prefixed("^", forms), prefixed("~", forms)...)
+		},
+		version: semverString,
+	},
+	StyleNPM: {
+		parse: ParseNPM,
+		and:   " ", or: " || ", any: "*",
+		shorthands: func(forms []string) []string {
+			return append(prefixed("^", forms), prefixed("~", forms)...)
+		},
+		version: semverString,
+	},
+	StyleCargo: {
+		parse: ParseCargo,
+		and:   ", ", any: "*", exact: "=", releaseOnly: true,
+		shorthands: func(forms []string) []string {
+			// bare versions are caret requirements, shorter forms get the operator for clarity.
+			candidates := append([]string{forms[0]}, prefixed("^", forms[1:])...)
+			return append(candidates, prefixed("~", forms)...)
+		},
+		version: semverString,
+	},
+	StylePEP440: {
+		parse: ParsePEP440,
+		and:   ", ", exact: "==", exclusions: true, prefixExclusions: true, releaseOnly: true,
+		shorthands: func(forms []string) []string {
+			var candidates []string
+			for _, form := range forms[1:] {
+				candidates = append(candidates, "=="+form+".*")
+			}
+			for _, form := range forms {
+				if strings.Contains(form, ".") {
+					candidates = append(candidates, "~="+form)
+				}
+			}
+			return candidates
+		},
+		version: pep440String,
+	},
+	StyleInterval: {releaseOnly: true},
+}
+
+// canonical rewrites bounds touching x into the bounds of the next version,
+// e.g. <=1.2.x := <1.3.0-0 and >1.x.x := >=2.0.0-0.
+func (f formatter) canonical(r Range) Range {
+	if !r.MaxUnbounded && !r.MaxExclusive {
+		if next, ok := nextRelease(r.Max); ok {
+			r.Max, r.MaxExclusive = next, true
+		}
+	}
+	if !r.MinUnbounded && r.MinExclusive {
+		if next, ok := nextRelease(r.Min); ok {
+			r.Min, r.MinExclusive = next, false
+		}
+	}
+
+	if f.releaseOnly {
+		// no pre-release is matched implicitly,
+		// so <1.3.0-0 and <1.3.0 allow the same versions.
+		if !r.MaxUnbounded && r.MaxExclusive && isPreZero(r.Max) {
+			r.Max.PreRelease = nil
+		}
+		if !r.MinUnbounded && !r.MinExclusive && isPreZero(r.Min) {
+			r.Min.PreRelease = nil
+		}
+	}
+	return r
+}
+
+// hasMaxNumber returns true if a bound of the range contains the <max> number reserved for wildcards.
+func hasMaxNumber(r Range) bool {
+	isMax := func(v Version) bool {
+		return v.Major == maxUint64 || v.Minor == maxUint64 || v.Patch == maxUint64
+	}
+	return !r.MinUnbounded && isMax(r.Min) || !r.MaxUnbounded && isMax(r.Max)
+}
+
+// isPreZero returns true for versions with the lowest pre-release -0.
+func isPreZero(v Version) bool {
+	if len(v.PreRelease) != 1 {
+		return false
+	}
+	n, ok := v.PreRelease[0].GetNumber()
+	return ok && n == 0
+}
+
+// formatRange prints a single canonical range.
+func (f formatter) formatRange(r Range) (string, error) {
+	switch {
+	case r.MinUnbounded && r.MaxUnbounded:
+		return f.any, nil
+	case r.isSingleVersion():
+		v, err := f.version(r.Min)
+		return f.exact + v, err
+	}
+
+	var comparators []string
+	if !r.MinUnbounded {
+		lower, err := f.version(r.Min)
+		if err != nil {
+			return "", err
+		}
+		if !r.MinExclusive && !r.MaxUnbounded && r.MaxExclusive {
+			for _, s := range f.shorthands(versionForms(lower, r.Min)) {
+				if f.matches(s, r) {
+					return s, nil
+				}
+			}
+		}
+		op := ">="
+		if r.MinExclusive {
+			op = ">"
+		}
+		comparators = append(comparators, op+lower)
+	}
+	if !r.MaxUnbounded {
+		upper, err := f.version(r.Max)
+		if err != nil {
+			return "", err
+		}
+		op := "<="
+		if r.MaxExclusive {
+			op = "<"
+		}
+		comparators = append(comparators, op+upper)
+	}
+	return strings.Join(comparators, f.and), nil
+}
+
+// matches checks whether the shorthand allows exactly the versions of the range.
+func (f formatter) matches(shorthand string, r Range) bool {
+	c, err := f.parse(shorthand)
+	if err != nil {
+		return false
+	}
+	rs := Normalize(c)
+	if len(rs) != 1 {
+		return false
+	}
+	parsed := f.canonical(rs[0])
+	return parsed.Same(r)
+}
+
+// formatExclusions prints ranges that are only separated by single versions or prefixes
+// as their hull and != clauses, e.g. ^1.2.3 !=1.5.0.
+// It returns false, if a gap can not be written as != clause.
+func (f formatter) formatExclusions(rs RangeSet) (string, bool, error) {
+	var clauses []string
+	hull := rs[0]
+	hull.setUpper(rs[len(rs)-1].upper())
+	if !hull.MinUnbounded || !hull.MaxUnbounded {
+		s, err := f.formatRange(hull)
+		if err != nil {
+			return "", false, err
+		}
+		clauses = append(clauses, s)
+	}
+
+	for i := 1; i < len(rs); i++ {
+		s, ok, err := f.gap(rs[i-1], rs[i])
+		if !ok || err != nil {
+			return "", false, err
+		}
+		clauses = append(clauses, s)
+	}
+	return strings.Join(clauses, f.and), true, nil
+}
+
+// gap returns the != clause excluding the versions between two ranges,
+// e.g. !=1.5.0 for <1.5.0 || >1.5.0, or !=1.5.* for <1.5.0 || >=1.6.0 with prefix exclusions.
+func (f formatter) gap(before, after Range) (string, bool, error) {
+	if before.MaxUnbounded || !before.MaxExclusive || after.MinUnbounded {
+		return "", false, nil
+	}
+
+	if after.MinExclusive {
+		if !before.Max.Same(after.Min) {
+			return "", false, nil
+		}
+		v, err := f.version(before.Max)
+		return "!=" + v, true, err
+	}
+
+	if !f.prefixExclusions {
+		return "", false, nil
+	}
+	v := before.Max
+	if len(v.PreRelease) > 0 || len(v.BuildMetadata) > 0 || v.Patch != 0 {
+		return "", false, nil
+	}
+	if after.Min.Same(Version{Major: v.Major, Minor: v.Minor + 1}) {
+		return fmt.Sprintf("!=%d.%d.*", v.Major, v.Minor), true, nil
+	}
+	if v.Minor == 0 && after.Min.Same(Version{Major: v.Major + 1}) {
+		return fmt.Sprintf("!=%d.*", v.Major), true, nil
+	}
+	return "", false, nil
+}
+
+// versionForms returns the printed version followed by its shorter forms,
+// leaving out trailing zeros, e.g. 1.0.0, 1.0 and 1.
+func versionForms(full string, v Version) []string {
+	forms := []string{full}
+	if len(v.PreRelease) > 0 || len(v.BuildMetadata) > 0 || v.Patch != 0 {
+		return forms
+	}
+	forms = append(forms, fmt.Sprintf("%d.%d", v.Major, v.Minor))
+	if v.Minor == 0 {
+		forms = append(forms, strconv.FormatUint(v.Major, 10))
+	}
+	return forms
+}
+
+func prefixed(prefix string, forms []string) []string {
+	out := make([]string, len(forms))
+	for i := range forms {
+		out[i] = prefix + forms[i]
+	}
+	return out
+}
+
+func semverString(v Version) (string, error) {
+	return v.String(), nil
+}
+
+// pep440String prints a version with alpha, beta or rc pre-release in PEP 440 syntax,
+// e.g. 1.2.3rc1 for 1.2.3-rc.1. Build metadata is dropped.
+func pep440String(v Version) (string, error) {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) == 0 {
+		return s, nil
+	}
+
+	labels := map[string]string{"alpha": "a", "beta": "b", "rc": "rc"}
+	if len(v.PreRelease) == 2 {
+		label, isLabel := v.PreRelease[0].GetString()
+		num, isNum := v.PreRelease[1].GetNumber()
+		if short, ok := labels[label]; ok && isLabel && isNum {
+			return s + short + strconv.FormatUint(num, 10), nil
+		}
+	}
+	return "", fmt.Errorf("%w in %s syntax: pre-release %s", ErrNotExpressible, StylePEP440, v.PreRelease.String())
+}
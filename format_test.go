@@ -0,0 +1,155 @@
+package semver
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		style    FormatStyle
+		expected string
+	}{
+		{input: "^1.2.3", style: StyleNative, expected: "^1.2.3"},
+		{input: ">=1.2.0 <2.0.0-0", style: StyleNative, expected: "^1.2.0"},
+		{input: "~1.2.3", style: StyleNative, expected: "~1.2.3"},
+		{input: "^0.0", style: StyleNative, expected: "^0.0.0"},
+		{input: "^1.2.3 && !=1.5.0", style: StyleNative, expected: "^1.2.3 !=1.5.0"},
+		{input: "!=1.2.3", style: StyleNative, expected: "!=1.2.3"},
+		{input: "~1.2 || >=2", style: StyleNative, expected: "~1.2.0 || >=2.0.0"},
+		{input: ">1.2.3 <2", style: StyleNative, expected: ">1.2.3 <2.0.0"},
+		{input: "=1.2.3", style: StyleNative, expected: "=1.2.3"},
+		{input: "0 - 2", style: StyleNative, expected: ">=0.0.0 <=2.0.0"},
+		{input: "0.0.0 - 2.0.0 && !=2.0.0", style: StyleNative, expected: ">=0.0.0 <2.0.0"},
+		{input: "<2 && !=1 && >0 || =2.2", style: StyleNative, expected: ">=1.0.0-0 <1.0.0 || >=2.0.0-0 <2.0.0 || ~2.2.0"},
+
+		{input: "^1.2.3", style: StyleNPM, expected: "^1.2.3"},
+		{input: "~1.2", style: StyleNPM, expected: "~1.2.0"},
+		{input: "^0.0.3", style: StyleNPM, expected: "~0.0.3"},
+		{input: ">=0.0.3 <0.0.4-0", style: StyleNPM, expected: "^0.0.3"},
+		{input: "~1.2 || >=2", style: StyleNPM, expected: "~1.2.0 || >=2.0.0"},
+		{input: "!=1.2.3", style: StyleNPM, expected: "<1.2.3 || >1.2.3"},
+		{input: "=1.2.3", style: StyleNPM, expected: "1.2.3"},
+		{input: ">=1.2 <1.5", style: StyleNPM, expected: ">=1.2.0 <1.5.0"},
+
+		{input: "^1.2.3", style: StyleCargo, expected: "1.2.3"},
+		{input: "~1.2", style: StyleCargo, expected: "~1.2.0"},
+		{input: "^0.0", style: StyleCargo, expected: "^0.0"},
+		{input: ">=1.2 <1.5", style: StyleCargo, expected: ">=1.2.0, <1.5.0"},
+
+		{input: "^1.2.3", style: StylePEP440, expected: ">=1.2.3, <2.0.0"},
+		{input: "^1.2", style: StylePEP440, expected: "~=1.2"},
+		{input: "~1.2", style: StylePEP440, expected: "==1.2.*"},
+		{input: "~1.2.3", style: StylePEP440, expected: "~=1.2.3"},
+		{input: "~1.2.3-rc.1", style: StylePEP440, expected: "~=1.2.3rc1"},
+		{input: "^1.2 && !=1.5.0", style: StylePEP440, expected: "~=1.2, !=1.5.0"},
+		{input: ">=1.2 <1.5 || >=1.6 <2", style: StylePEP440, expected: "~=1.2, !=1.5.*"},
+		{input: "=1.2.3", style: StylePEP440, expected: "==1.2.3"},
+		{input: ">=1.2.3", style: StylePEP440, expected: ">=1.2.3"},
+
+		{input: "~1.2 || >=2", style: StyleInterval, expected: "[1.2.0,1.3.0),[2.0.0,)"},
+		{input: "<2 && !=1 && >0 || =2.2", style: StyleInterval, expected: "[2.2.0,2.3.0)"},
+	}
+	for _, test := range tests {
+		t.Run(test.style.String()+" "+test.input, func(t *testing.T) {
+			t.Parallel()
+			s, err := Format(MustNewConstraint(test.input), test.style)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, s)
+		})
+	}
+}
+
+// formatted constraints parse back into the same ranges.
+func TestFormat_roundTrip(t *testing.T) {
+	t.Parallel()
+	parsers := map[FormatStyle]func(string) (Constraint, error){
+		StyleNative:   func(s string) (Constraint, error) { return NewConstraint(s) },
+		StyleNPM:      ParseNPM,
+		StyleCargo:    ParseCargo,
+		StylePEP440:   ParsePEP440,
+		StyleInterval: ParseInterval,
+	}
+	inputs := []string{
+		"^1.2.3", "~1.2.3", "^0.0.3", "^0.0", "^1.2.3 && !=1.5.0", "!=1.2.3",
+		"~1.2 || >=2", ">1.2.3 <2", "<=1.2.3", "=1.2.3-rc.1", "~1.2.3-rc.1",
+		">1.2.x", "<=1.x", ">=1.2 <1.5 || >=1.6 <2", "^1.2 && !=1.5.0",
+		"0 - 2", ">=0.0.0", "<=2.0.0", "0.0.0 - 2.0.0 && !=2.0.0", "0.0.0 - 2.0.0 && !=1.0.0",
+		"0.0.0 - 2.0.0-rc.1 && !=2.0.0-rc.1",
+		"<2 && !=1 && >0 || =2.2", "<2 && !=1 && >0",
+	}
+	// all other inputs have to round-trip.
+	notExpressible := map[FormatStyle][]string{
+		StyleCargo: {
+			"!=1.2.3", "0.0.0 - 2.0.0 && !=1.0.0", "<2 && !=1 && >0", ">=1.2 <1.5 || >=1.6 <2",
+			"^1.2 && !=1.5.0", "^1.2.3 && !=1.5.0", "~1.2 || >=2",
+		},
+		StyleInterval: {"<2 && !=1 && >0"},
+		StylePEP440:   {"<2 && !=1 && >0", "~1.2 || >=2"},
+	}
+	for style, parse := range parsers {
+		for _, input := range inputs {
+			t.Run(style.String()+" "+input, func(t *testing.T) {
+				t.Parallel()
+				c := MustNewConstraint(input)
+				s, err := Format(c, style)
+				if slices.Contains(notExpressible[style], input) {
+					require.ErrorIs(t, err, ErrNotExpressible, s)
+					return
+				}
+				require.NoError(t, err)
+
+				parsed, err := parse(s)
+				require.NoError(t, err, s)
+				assert.True(t, Equal(canonicalRanges(c, style), canonicalRanges(parsed, style)),
+					"%s: %s != %s", s, Normalize(c), Normalize(parsed))
+			})
+		}
+	}
+}
+
+func TestFormat_notExpressible(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		style FormatStyle
+	}{
+		{input: "~1.2 || >=2", style: StyleCargo},
+		{input: "!=1.2.3", style: StyleCargo},
+		{input: "~1.2 || >=2", style: StylePEP440},
+		{input: "=1.2.3-1", style: StylePEP440},
+		{input: ">=18446744073709551615", style: StyleInterval},
+		{input: ">=18446744073709551615", style: StyleNative},
+		{input: "<=18446744073709551615.x", style: StyleInterval},
+		{input: "<=18446744073709551615.x", style: StyleNPM},
+		{input: "<2 && !=1 && >0", style: StyleInterval},
+	}
+	for _, test := range tests {
+		t.Run(test.style.String()+" "+test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := Format(MustNewConstraint(test.input), test.style)
+			require.ErrorIs(t, err, ErrNotExpressible)
+		})
+	}
+
+	_, err := Format(RangeSet{}, StyleNative)
+	require.ErrorIs(t, err, ErrNotExpressible)
+}
+
+// canonicalRanges allows comparing ranges with x and -0 bounds, e.g. <=1.x.x and <2.0.0-0,
+// dropping ranges that allow no version in the style.
+func canonicalRanges(c Constraint, style FormatStyle) RangeSet {
+	var out RangeSet
+	for _, r := range Normalize(c) {
+		r = formatters[style].canonical(r)
+		if !r.isEmpty() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
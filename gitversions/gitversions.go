@@ -0,0 +1,228 @@
+// Package gitversions discovers semantic versions from the tags of a local git repository.
+//
+// Tags are read from the repository files directly,
+// loose tags from .git/refs/tags and packed tags from .git/packed-refs,
+// without calling git or accessing the network.
+package gitversions
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"pkg.package-operator.run/semver"
+)
+
+const tagsRef = "refs/tags/"
+
+// Options configure how tags are turned into versions.
+type Options struct {
+	// Prefix that tags have to start with, e.g. v or component/v.
+	// Tags without the prefix are ignored and the prefix is cut before parsing.
+	Prefix string
+	// ParseOptions passed to semver.NewVersion.
+	ParseOptions semver.ParseOptions
+}
+
+// ApplyToOptions implements Option.
+// Only set options are applied.
+func (o Options) ApplyToOptions(opts *Options) {
+	if len(o.Prefix) > 0 {
+		opts.Prefix = o.Prefix
+	}
+	o.ParseOptions.ApplyToParseOptions(&opts.ParseOptions)
+}
+
+// Option can be passed to Read.
+type Option interface {
+	ApplyToOptions(opts *Options)
+}
+
+// Prefix that tags have to start with, e.g. v or component/v.
+type Prefix string
+
+// ApplyToOptions implements Option.
+func (p Prefix) ApplyToOptions(opts *Options) {
+	opts.Prefix = string(p)
+}
+
+// Result of reading the tags of a repository.
+type Result struct {
+	// Versions of all tags, sorted ascending.
+	Versions semver.VersionList
+	// Skipped tags having the prefix, that are not valid versions.
+	Skipped []SkippedTag
+
+	tags map[string]string // version string to tag name
+}
+
+// TagName returns the name of the tag a version was read from,
+// e.g. v1.2.3 for 1.2.3 with Prefix v.
+func (r *Result) TagName(v semver.Version) (string, bool) {
+	name, ok := r.tags[v.String()]
+	return name, ok
+}
+
+// Latest returns the highest version satisfying the constraint, e.g. the latest tag matching ~1.4.
+func (r *Result) Latest(c semver.Constraint) (semver.Version, bool) {
+	return r.Versions.MaxSatisfying(c)
+}
+
+// SkippedTag is a tag that could not be parsed as version.
+type SkippedTag struct {
+	// Name of the tag, including the prefix.
+	Name string
+	// Err returned by semver.NewVersion.
+	Err error
+}
+
+// Error returns the tag name and why it was skipped.
+func (s SkippedTag) Error() string {
+	return fmt.Sprintf("tag %s: %s", s.Name, s.Err)
+}
+
+// Unwrap returns the parse error.
+func (s SkippedTag) Unwrap() error {
+	return s.Err
+}
+
+// Read returns the versions of all tags of the repository at path.
+// Path may point to the working tree, a .git directory or a bare repository.
+func Read(path string, opts ...Option) (Result, error) {
+	var options Options
+	for _, opt := range opts {
+		opt.ApplyToOptions(&options)
+	}
+
+	gitDir, err := findGitDir(path)
+	if err != nil {
+		return Result{}, err
+	}
+	names, err := tagNames(gitDir)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res := Result{tags: map[string]string{}}
+	for _, name := range names {
+		s, ok := strings.CutPrefix(name, options.Prefix)
+		if !ok {
+			continue
+		}
+		v, err := semver.NewVersion(s, options.ParseOptions)
+		if err != nil {
+			res.Skipped = append(res.Skipped, SkippedTag{Name: name, Err: err})
+			continue
+		}
+		if _, exists := res.tags[v.String()]; !exists {
+			res.tags[v.String()] = name
+		}
+		res.Versions = append(res.Versions, v)
+	}
+	sort.Stable(semver.Ascending(res.Versions))
+	return res, nil
+}
+
+// findGitDir returns the directory holding refs and packed-refs.
+// Worktrees and submodules have a .git file pointing to their git dir,
+// which shares tags with the main repository via its commondir.
+func findGitDir(path string) (string, error) {
+	gitDir := path
+	dotGit := filepath.Join(path, ".git")
+	switch info, err := os.Stat(dotGit); {
+	case err == nil && info.IsDir():
+		gitDir = dotGit
+	case err == nil:
+		data, err := os.ReadFile(dotGit)
+		if err != nil {
+			return "", err
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+		if !ok {
+			return "", fmt.Errorf("%s: missing gitdir", dotGit)
+		}
+		gitDir = resolve(path, target)
+	case !errors.Is(err, fs.ErrNotExist):
+		return "", err
+	}
+
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		gitDir = resolve(gitDir, strings.TrimSpace(string(data)))
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "HEAD")); err != nil {
+		return "", fmt.Errorf("%s is not a git repository: %w", path, err)
+	}
+	return gitDir, nil
+}
+
+// resolve returns target relative to base, if target is not absolute.
+func resolve(base, target string) string {
+	if filepath.IsAbs(target) {
+		return target
+	}
+	return filepath.Join(base, target)
+}
+
+// tagNames returns the names of all loose and packed tags in order.
+func tagNames(gitDir string) ([]string, error) {
+	var names []string
+	tagsDir := filepath.Join(gitDir, filepath.FromSlash(tagsRef))
+	err := filepath.WalkDir(tagsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(tagsDir, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	packed, err := packedTagNames(filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return nil, err
+	}
+	names = append(names, packed...)
+	slices.Sort(names)
+	// tags are packed and loose at the same time after updates.
+	return slices.Compact(names), nil
+}
+
+// packedTagNames returns the names of tags in a packed-refs file:
+//
+//	# pack-refs with: peeled fully-peeled sorted
+//	0d4e7a52b9c1d3e5f6a7b8c9d0e1f2a3b4c5d6e7 refs/tags/v1.2.3
+//	^a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0
+func packedTagNames(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		_, ref, ok := strings.Cut(s.Text(), " ")
+		if !ok || strings.HasPrefix(s.Text(), "#") {
+			continue
+		}
+		if name, ok := strings.CutPrefix(ref, tagsRef); ok {
+			names = append(names, name)
+		}
+	}
+	return names, s.Err()
+}
@@ -0,0 +1,134 @@
+package gitversions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pkg.package-operator.run/semver"
+)
+
+const hash = "0d4e7a52b9c1d3e5f6a7b8c9d0e1f2a3b4c5d6e7"
+
+// newRepo creates a minimal .git directory with loose and packed tags.
+func newRepo(t *testing.T, loose []string, packedRefs string) string {
+	t.Helper()
+	dir := t.TempDir()
+	gitDir := filepath.Join(dir, ".git")
+	writeFile(t, filepath.Join(gitDir, "HEAD"), "ref: refs/heads/main\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(gitDir, "refs", "tags"), 0o755))
+	for _, name := range loose {
+		writeFile(t, filepath.Join(gitDir, "refs", "tags", filepath.FromSlash(name)), hash+"\n")
+	}
+	if len(packedRefs) > 0 {
+		writeFile(t, filepath.Join(gitDir, "packed-refs"), packedRefs)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+const packedRefs = `# pack-refs with: peeled fully-peeled sorted
+` + hash + ` refs/heads/main
+` + hash + ` refs/tags/v1.2.0
+^a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0
+` + hash + ` refs/tags/v1.4.0-rc.1
+` + hash + ` refs/tags/v1.10.0
+` + hash + ` refs/tags/operator/v2.0.0
+`
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	repo := newRepo(t, []string{"v1.4.2", "v1.4.0", "v1.2.0", "latest", "v1.5", "operator/v2.1.0"}, packedRefs)
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		versions string
+		skipped  []string
+	}{
+		{
+			name:     "v prefix",
+			opts:     []Option{Prefix("v")},
+			versions: "1.2.0, 1.4.0-rc.1, 1.4.0, 1.4.2, 1.10.0",
+			skipped:  []string{"v1.5"},
+		},
+		{
+			name:     "lenient",
+			opts:     []Option{Options{Prefix: "v", ParseOptions: semver.Lenient}},
+			versions: "1.2.0, 1.4.0-rc.1, 1.4.0, 1.4.2, 1.5.0, 1.10.0",
+		},
+		{
+			name:     "component prefix",
+			opts:     []Option{Prefix("operator/v")},
+			versions: "2.0.0, 2.1.0",
+		},
+		{
+			name: "no prefix",
+			skipped: []string{
+				"latest", "operator/v2.0.0", "operator/v2.1.0",
+				"v1.10.0", "v1.2.0", "v1.4.0", "v1.4.0-rc.1", "v1.4.2", "v1.5",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := Read(repo, test.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, test.versions, res.Versions.String())
+
+			var skipped []string
+			for _, s := range res.Skipped {
+				skipped = append(skipped, s.Name)
+				require.ErrorAs(t, s, new(*semver.ParseError))
+			}
+			assert.Equal(t, test.skipped, skipped)
+		})
+	}
+}
+
+func TestResult_Latest(t *testing.T) {
+	t.Parallel()
+	repo := newRepo(t, []string{"v1.4.2", "v1.4.0", "v1.5.0"}, packedRefs)
+	res, err := Read(filepath.Join(repo, ".git"), Prefix("v"))
+	require.NoError(t, err)
+
+	v, ok := res.Latest(semver.MustNewConstraint("~1.4"))
+	require.True(t, ok)
+	assert.Equal(t, "1.4.2", v.String())
+	name, ok := res.TagName(v)
+	require.True(t, ok)
+	assert.Equal(t, "v1.4.2", name)
+
+	_, ok = res.Latest(semver.MustNewConstraint("^3"))
+	assert.False(t, ok)
+}
+
+func TestRead_worktree(t *testing.T) {
+	t.Parallel()
+	repo := newRepo(t, []string{"v1.0.0"}, "")
+	worktreeGitDir := filepath.Join(repo, ".git", "worktrees", "feature")
+	writeFile(t, filepath.Join(worktreeGitDir, "HEAD"), hash+"\n")
+	writeFile(t, filepath.Join(worktreeGitDir, "commondir"), "../..\n")
+
+	worktree := t.TempDir()
+	writeFile(t, filepath.Join(worktree, ".git"), "gitdir: "+worktreeGitDir+"\n")
+
+	res, err := Read(worktree, Prefix("v"))
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", res.Versions.String())
+}
+
+func TestRead_notRepository(t *testing.T) {
+	t.Parallel()
+	_, err := Read(t.TempDir())
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
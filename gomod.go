@@ -0,0 +1,172 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// goIncompatible is the only build metadata allowed in Go module versions.
+const goIncompatible = "incompatible"
+
+// goPseudoTimeLayout is the UTC timestamp of pseudo-versions, e.g. 20240101120000.
+const goPseudoTimeLayout = "20060102150405"
+
+// goPseudoRevisionLen is the length of the abbreviated commit hash of pseudo-versions.
+const goPseudoRevisionLen = 12
+
+// ParseGoVersion parses a Go module version as found in go.mod files,
+// e.g. v1.2.3, v2.0.0+incompatible or v0.0.0-20240101120000-abcdef123456.
+//
+// Differences to NewVersion:
+//   - the v prefix is required
+//   - +incompatible is the only build metadata allowed, and requires major version 2 or higher
+//   - pre-releases shaped like pseudo-versions need a valid timestamp and revision, see Version.PseudoVersion
+//
+// Go compares module versions like SemVer, so the returned Version can be
+// compared, sorted and checked against constraints like any other Version.
+func ParseGoVersion(s string) (Version, error) {
+	if len(s) == 0 {
+		return Version{}, errorAtOffset(s, 0, ErrEmptyInput, "empty")
+	}
+	if s[0] != 'v' {
+		return Version{}, errorAtOffset(s, 0, ErrInvalidCharacter, "Go module versions start with v")
+	}
+
+	v, err := NewVersion(s[1:])
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		// report positions including the v prefix.
+		perr.Pos++
+		perr.Input = s
+	}
+	if err != nil {
+		return Version{}, err
+	}
+
+	if len(v.BuildMetadata) > 0 {
+		offset := strings.IndexByte(s, '+')
+		if len(v.BuildMetadata) > 1 || v.BuildMetadata[0] != goIncompatible {
+			return Version{}, errorAtOffset(s, offset, ErrInvalidIdentifier,
+				"build metadata other than +%s", goIncompatible)
+		}
+		if v.Major < 2 {
+			return Version{}, errorAtOffset(s, offset, ErrInvalidIdentifier,
+				"+%s requires major version 2 or higher", goIncompatible)
+		}
+	}
+
+	if ts, rev, ok := pseudoVersionParts(v); ok {
+		if _, err := time.Parse(goPseudoTimeLayout, ts); err != nil {
+			return Version{}, errorAtOffset(s, strings.Index(s, ts), ErrInvalidIdentifier,
+				"invalid pseudo-version timestamp %s", ts)
+		}
+		if !isPseudoRevision(rev) {
+			return Version{}, errorAtOffset(s, strings.Index(s, ts)+len(ts)+1, ErrInvalidIdentifier,
+				"invalid pseudo-version revision %s, expected %d lowercase hex digits", rev, goPseudoRevisionLen)
+		}
+	}
+	return v, nil
+}
+
+// GoVersion returns the version in Go module syntax, e.g. v1.2.3 or v2.0.0+incompatible.
+func (v *Version) GoVersion() string {
+	return "v" + v.String()
+}
+
+// Incompatible returns true for Go module versions with +incompatible build metadata,
+// which mark major versions 2 or higher of modules without go.mod file or /vN module path.
+func (v *Version) Incompatible() bool {
+	return len(v.BuildMetadata) == 1 && v.BuildMetadata[0] == goIncompatible
+}
+
+// PseudoVersion describes a Go module pseudo-version,
+// which refers to a commit that has no version tag.
+type PseudoVersion struct {
+	// Base is the tagged version the commit builds upon, e.g. v1.2.3 for v1.2.4-0.20240101120000-abcdef123456.
+	// Pseudo-versions like v0.0.0-20240101120000-abcdef123456 have no Base.
+	Base Version
+	// HasBase is true, if the pseudo-version has a Base.
+	HasBase bool
+	// Time is the UTC commit time.
+	Time time.Time
+	// Revision is the abbreviated commit hash, e.g. abcdef123456.
+	Revision string
+}
+
+// PseudoVersion returns the details of a Go module pseudo-version, or false for other versions.
+// It understands all three forms of pseudo-versions:
+//   - vX.0.0-yyyymmddhhmmss-abcdefabcdef without base version
+//   - vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef with base version vX.Y.Z-pre
+//   - vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef with base version vX.Y.Z
+func (v *Version) PseudoVersion() (PseudoVersion, bool) {
+	ts, rev, ok := pseudoVersionParts(*v)
+	if !ok || !isPseudoRevision(rev) {
+		return PseudoVersion{}, false
+	}
+	t, err := time.Parse(goPseudoTimeLayout, ts)
+	if err != nil {
+		return PseudoVersion{}, false
+	}
+	p := PseudoVersion{Time: t, Revision: rev}
+
+	n := len(v.PreRelease)
+	if n == 1 {
+		return p, true
+	}
+	p.HasBase = true
+	if n == 2 {
+		p.Base = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch - 1}
+	} else {
+		p.Base = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, PreRelease: v.PreRelease[:n-2]}
+	}
+	if v.Incompatible() {
+		p.Base.BuildMetadata = []string{goIncompatible}
+	}
+	return p, true
+}
+
+// pseudoVersionParts returns the timestamp and revision of versions shaped like a pseudo-version.
+func pseudoVersionParts(v Version) (ts, rev string, ok bool) {
+	n := len(v.PreRelease)
+	if n == 0 {
+		return "", "", false
+	}
+	last, isString := v.PreRelease[n-1].GetString()
+	if !isString {
+		return "", "", false
+	}
+	ts, rev, found := strings.Cut(last, "-")
+	if !found || len(ts) != len(goPseudoTimeLayout) || !isDigits(ts) ||
+		len(rev) == 0 || strings.ContainsRune(rev, '-') {
+		return "", "", false
+	}
+
+	switch {
+	case n == 1:
+		// vX.0.0-yyyymmddhhmmss-abcdefabcdef
+		return ts, rev, v.Minor == 0 && v.Patch == 0
+	case isNumber(v.PreRelease[n-2], 0):
+		// vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef needs Z+1 > 0.
+		return ts, rev, n > 2 || v.Patch > 0
+	}
+	return "", "", false
+}
+
+// isPseudoRevision returns true for abbreviated commit hashes, e.g. abcdef123456.
+func isPseudoRevision(rev string) bool {
+	if len(rev) != goPseudoRevisionLen {
+		return false
+	}
+	for _, r := range rev {
+		if !isDigit(r) && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumber(id PreReleaseIdentifier, num uint64) bool {
+	n, ok := id.GetNumber()
+	return ok && n == num
+}
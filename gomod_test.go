@@ -0,0 +1,175 @@
+package semver
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoVersion(t *testing.T) {
+	t.Parallel()
+	tests := []string{
+		"v0.0.0",
+		"v1.2.3",
+		"v1.2.3-rc.1",
+		"v2.0.0+incompatible",
+		"v0.0.0-20240101120000-abcdef123456",
+		"v1.2.4-0.20240101120000-abcdef123456",
+		"v1.2.3-rc.1.0.20240101120000-abcdef123456",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			t.Parallel()
+			v, err := ParseGoVersion(test)
+			require.NoError(t, err)
+			assert.Equal(t, test, v.GoVersion())
+		})
+	}
+}
+
+func TestParseGoVersion_error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		kind  error
+		err   string
+	}{
+		{input: "", kind: ErrEmptyInput, err: `col 1: empty`},
+		{input: "1.2.3", kind: ErrInvalidCharacter, err: `col 1: Go module versions start with v`},
+		{input: "v1.2", kind: ErrMissingSegment},
+		{input: "v1.2.3+build", kind: ErrInvalidIdentifier, err: `col 7: build metadata other than +incompatible`},
+		{
+			input: "v1.2.3+incompatible", kind: ErrInvalidIdentifier,
+			err: `col 7: +incompatible requires major version 2 or higher`,
+		},
+		{
+			input: "v0.0.0-20241301120000-abcdef123456", kind: ErrInvalidIdentifier,
+			err: `col 8: invalid pseudo-version timestamp 20241301120000`,
+		},
+		{
+			input: "v0.0.0-20240101120000-abc", kind: ErrInvalidIdentifier,
+			err: `col 23: invalid pseudo-version revision abc, expected 12 lowercase hex digits`,
+		},
+		{
+			input: "v1.2.4-0.20240101120000-ABCDEF123456", kind: ErrInvalidIdentifier,
+			err: `col 25: invalid pseudo-version revision ABCDEF123456, expected 12 lowercase hex digits`,
+		},
+		{input: "v1.2.4-0.20240101120000-abcdefghijkl", kind: ErrInvalidIdentifier},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseGoVersion(test.input)
+			require.ErrorIs(t, err, test.kind)
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, test.input, perr.Input)
+			if len(test.err) > 0 {
+				assert.Equal(t, test.err, perr.Error())
+			}
+		})
+	}
+}
+
+func TestVersion_PseudoVersion(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input    string
+		expected PseudoVersion
+	}{
+		{
+			input:    "v0.0.0-20240101120000-abcdef123456",
+			expected: PseudoVersion{Time: ts, Revision: "abcdef123456"},
+		},
+		{
+			input: "v1.2.4-0.20240101120000-abcdef123456",
+			expected: PseudoVersion{
+				Base: MustNewVersion("1.2.3"), HasBase: true,
+				Time: ts, Revision: "abcdef123456",
+			},
+		},
+		{
+			input: "v1.2.3-rc.1.0.20240101120000-abcdef123456",
+			expected: PseudoVersion{
+				Base: MustNewVersion("1.2.3-rc.1"), HasBase: true,
+				Time: ts, Revision: "abcdef123456",
+			},
+		},
+		{
+			input: "v2.0.1-0.20240101120000-abcdef123456+incompatible",
+			expected: PseudoVersion{
+				Base: MustNewVersion("2.0.0+incompatible"), HasBase: true,
+				Time: ts, Revision: "abcdef123456",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			v, err := ParseGoVersion(test.input)
+			require.NoError(t, err)
+			p, ok := v.PseudoVersion()
+			require.True(t, ok)
+			assert.Equal(t, test.expected, p)
+		})
+	}
+
+	for _, input := range []string{
+		"v1.2.3", "v1.2.3-rc.1", "v1.2.0-20240101120000-abcdef123456", "v1.2.0-0.20240101120000-abcdef123456",
+	} {
+		v, err := ParseGoVersion(input)
+		require.NoError(t, err)
+		_, ok := v.PseudoVersion()
+		assert.False(t, ok, input)
+	}
+
+	// NewVersion does not validate the revision.
+	v := MustNewVersion("0.0.0-20240101120000-main")
+	_, ok := v.PseudoVersion()
+	assert.False(t, ok)
+}
+
+func TestVersion_Incompatible(t *testing.T) {
+	t.Parallel()
+	v := MustNewVersion("2.0.0+incompatible")
+	assert.True(t, v.Incompatible())
+	v = MustNewVersion("2.0.0")
+	assert.False(t, v.Incompatible())
+}
+
+// Go module versions sort like go/x/mod/semver.
+func TestGoVersion_order(t *testing.T) {
+	t.Parallel()
+	expected := []string{
+		"v0.0.0-20230101120000-abcdef123456",
+		"v0.0.0-20240101120000-abcdef123456",
+		"v1.2.3-rc.1",
+		"v1.2.3-rc.1.0.20240101120000-abcdef123456",
+		"v1.2.3",
+		"v1.2.4-0.20240101120000-abcdef123456",
+		"v1.2.4",
+		"v2.0.0+incompatible",
+	}
+	var versions VersionList
+	for _, s := range slices.Backward(expected) {
+		v, err := ParseGoVersion(s)
+		require.NoError(t, err)
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, func(a, b Version) int { return a.Compare(b) })
+
+	var out []string
+	for _, v := range versions {
+		out = append(out, v.GoVersion())
+	}
+	assert.Equal(t, expected, out)
+
+	c := MustNewConstraint(">1.2.3 <1.2.4")
+	v, err := ParseGoVersion("v1.2.4-0.20240101120000-abcdef123456")
+	require.NoError(t, err)
+	assert.True(t, c.Check(v), "pseudo-versions sort between base and next patch")
+}
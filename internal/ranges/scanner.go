@@ -9,8 +9,18 @@ import (
 	"pkg.package-operator.run/semver/internal"
 )
 
+// ErrorKind classifies the errors reported to an ErrorHandler.
+type ErrorKind int
+
+const (
+	// InvalidCharacter is reported for characters that can not start or continue a token.
+	InvalidCharacter ErrorKind = iota
+	// NumberOverflow is reported for numbers exceeding uint64.
+	NumberOverflow
+)
+
 // An ErrorHandler may be provided to Scanner.Init.
-type ErrorHandler func(pos internal.Position, msg string)
+type ErrorHandler func(pos internal.Position, kind ErrorKind, msg string)
 
 // Scanner implements a scanner for systemd unit files.
 // It takes a []byte as source which can then be tokenized
@@ -25,6 +35,14 @@ type Scanner struct {
 	offset   int  // character offset
 	rdOffset int  // reading offset (position after current character)
 
+	// token state
+	start, end int // byte offsets of the last token
+
+	// version state
+	prev Token  // previous token
+	dots int    // number of dots in the current version
+	text string // literal of the last PRERELEASE or BUILD token
+
 	ErrorCount int // number of errors encountered
 }
 
@@ -36,14 +54,21 @@ func (s *Scanner) Init(src []byte, errHandler ErrorHandler) {
 	s.ch = ' '
 	s.offset = 0
 	s.rdOffset = 0
+	s.prev = ILLEGAL
+	s.dots = 0
+	s.text = ""
 	s.ErrorCount = 0
 
 	s.next()
 }
 
 func (s *Scanner) error(msg string) {
+	s.errorAt(s.pos, InvalidCharacter, msg)
+}
+
+func (s *Scanner) errorAt(pos internal.Position, kind ErrorKind, msg string) {
 	if s.err != nil {
-		s.err(s.pos, msg)
+		s.err(pos, kind, msg)
 	}
 	s.ErrorCount++
 }
@@ -96,6 +121,34 @@ func (s *Scanner) scanSpace() string {
 	return string(s.src[offs:s.offset])
 }
 
+// scanSuffix scans pre-release or build identifiers
+// until the end of the version.
+func (s *Scanner) scanSuffix(stopAtPlus bool) string {
+	offs := s.offset
+	for s.ch != -1 && s.ch != ' ' &&
+		s.ch != '|' && s.ch != ',' && s.ch != '&' &&
+		(!stopAtPlus || s.ch != '+') {
+		s.next()
+	}
+	return string(s.src[offs:s.offset])
+}
+
+// afterPatch returns true if the previous token completed a x.y.z version.
+func (s *Scanner) afterPatch() bool {
+	return s.prev == NUMBER && s.dots == 2
+}
+
+// Span returns the byte offsets [start, end) of the last token within the source.
+func (s *Scanner) Span() (start, end int) {
+	return s.start, s.end
+}
+
+// Text returns the identifiers of the last PRERELEASE or BUILD token,
+// without the leading - or +.
+func (s *Scanner) Text() string {
+	return s.text
+}
+
 func (s *Scanner) followedByEqual(tok0, tok1 Token) Token {
 	if s.ch == '=' {
 		s.next()
@@ -105,6 +158,22 @@ func (s *Scanner) followedByEqual(tok0, tok1 Token) Token {
 }
 
 func (s *Scanner) Scan() (pos internal.Position, tok Token, lit uint64) {
+	s.start = s.offset
+	pos, tok, lit = s.scan()
+	s.end = s.offset
+
+	switch tok {
+	case NUMBER, WILDCARD:
+	case DOT:
+		s.dots++
+	default:
+		s.dots = 0
+	}
+	s.prev = tok
+	return
+}
+
+func (s *Scanner) scan() (pos internal.Position, tok Token, lit uint64) {
 	pos = s.pos
 	ch := s.ch
 	s.next()
@@ -140,7 +209,21 @@ func (s *Scanner) Scan() (pos internal.Position, tok Token, lit uint64) {
 	case '.':
 		tok = DOT
 	case '-':
+		// 1.2.3-rc.1 is a pre-release, 1.2-1.3 or 1.2.3 - 1.3.0 a hyphen range.
+		if s.afterPatch() && s.ch != ' ' && s.ch != -1 {
+			tok = PRERELEASE
+			s.text = s.scanSuffix(true)
+			return
+		}
 		tok = HYPHEN
+	case '+':
+		if !s.afterPatch() && s.prev != PRERELEASE {
+			s.errorAt(pos, InvalidCharacter, fmt.Sprintf("unexpected character %#U", ch))
+			tok = ILLEGAL
+			return
+		}
+		tok = BUILD
+		s.text = s.scanSuffix(false)
 	case 'x', 'X', '*':
 		tok = WILDCARD
 	case '|':
@@ -175,7 +258,8 @@ func (s *Scanner) Scan() (pos internal.Position, tok Token, lit uint64) {
 			var err error
 			lit, err = strconv.ParseUint(num, 10, 0)
 			if err != nil {
-				panic(err)
+				s.errorAt(pos, NumberOverflow, fmt.Sprintf("number %s too large", num))
+				lit = 0
 			}
 			return
 		}
@@ -106,10 +106,54 @@ var example6tokens = []tokenEntry{
 	{tok: EOF},
 }
 
+const example7 = `1.0.0-rc.0 - 1.0.0-rc.10+b.1`
+
+var example7tokens = []tokenEntry{
+	{pos: 1, tok: NUMBER, lit: 1},
+	{pos: 2, tok: DOT},
+	{pos: 3, tok: NUMBER, lit: 0},
+	{pos: 4, tok: DOT},
+	{pos: 5, tok: NUMBER, lit: 0},
+	{pos: 6, tok: PRERELEASE, text: "rc.0"},
+	{pos: 11, tok: SPACE},
+	{pos: 12, tok: HYPHEN},
+	{pos: 13, tok: SPACE},
+	{pos: 14, tok: NUMBER, lit: 1},
+	{pos: 15, tok: DOT},
+	{pos: 16, tok: NUMBER, lit: 0},
+	{pos: 17, tok: DOT},
+	{pos: 18, tok: NUMBER, lit: 0},
+	{pos: 19, tok: PRERELEASE, text: "rc.10"},
+	{pos: 25, tok: BUILD, text: "b.1"},
+	{pos: 28, tok: EOF},
+}
+
+const example8 = `1.2-1.3||>=1.2.3+b`
+
+var example8tokens = []tokenEntry{
+	{tok: NUMBER, lit: 1},
+	{tok: DOT},
+	{tok: NUMBER, lit: 2},
+	{tok: HYPHEN},
+	{tok: NUMBER, lit: 1},
+	{tok: DOT},
+	{tok: NUMBER, lit: 3},
+	{tok: OR},
+	{tok: GREATER_EQUAL},
+	{tok: NUMBER, lit: 1},
+	{tok: DOT},
+	{tok: NUMBER, lit: 2},
+	{tok: DOT},
+	{tok: NUMBER, lit: 3},
+	{tok: BUILD, text: "b"},
+	{tok: EOF},
+}
+
 type tokenEntry struct {
-	pos internal.Position
-	tok Token
-	lit uint64
+	pos  internal.Position
+	tok  Token
+	lit  uint64
+	text string
 }
 
 func TestScanner(t *testing.T) {
@@ -152,6 +196,15 @@ func TestScanner(t *testing.T) {
 			Input:  example6,
 			Tokens: example6tokens,
 		},
+		{
+			Input:        example7,
+			Tokens:       example7tokens,
+			TestPosition: true,
+		},
+		{
+			Input:  example8,
+			Tokens: example8tokens,
+		},
 	}
 
 	for _, test := range tests {
@@ -170,6 +223,9 @@ func TestScanner(t *testing.T) {
 					tok: tok,
 					lit: lit,
 				}
+				if tok == PRERELEASE || tok == BUILD {
+					te.text = s.Text()
+				}
 				if test.TestPosition {
 					te.pos = pos
 				}
@@ -182,3 +238,23 @@ func TestScanner(t *testing.T) {
 		})
 	}
 }
+
+func TestScanner_numberOverflow(t *testing.T) {
+	t.Parallel()
+	type report struct {
+		pos  internal.Position
+		kind ErrorKind
+		msg  string
+	}
+	var reports []report
+	var s Scanner
+	s.Init([]byte(">=18446744073709551616"), func(pos internal.Position, kind ErrorKind, msg string) {
+		reports = append(reports, report{pos: pos, kind: kind, msg: msg})
+	})
+	s.Scan()
+	_, tok, lit := s.Scan()
+	assert.Equal(t, NUMBER, tok)
+	assert.Equal(t, uint64(0), lit)
+	assert.Equal(t, 1, s.ErrorCount)
+	assert.Equal(t, []report{{pos: 3, kind: NumberOverflow, msg: "number 18446744073709551616 too large"}}, reports)
+}
@@ -18,6 +18,8 @@ const (
 
 	// Values - Essentially everything that does not fit elsewhere.
 	NUMBER
+	PRERELEASE // -rc.1, identifiers available via Scanner.Text
+	BUILD      // +build.1, identifiers available via Scanner.Text
 
 	// Operators and delimiters.
 	EQUAL         // =
@@ -44,7 +46,9 @@ var tokens = [...]string{
 	SPACE:   "SPACE",
 
 	// Values
-	NUMBER: "NUMBER",
+	NUMBER:     "NUMBER",
+	PRERELEASE: "PRERELEASE",
+	BUILD:      "BUILD",
 
 	// Operators and delimiters
 	EQUAL:         "EQUAL",
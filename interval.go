@@ -0,0 +1,178 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// intervalVersionOptions accept the short versions common in Maven and NuGet, e.g. 1.0.
+var intervalVersionOptions = ParseOptions{FillMissing: true, AllowLeadingZeros: true}
+
+// ParseInterval parses a version range in the interval notation of Maven and NuGet into a Constraint,
+// e.g. "[1.0,2.0)", "(,1.0]" or "[1.2,1.3],[1.5,)".
+//
+//   - [ and ] include the bound, ( and ) exclude it
+//   - an empty bound is unbounded, e.g. [1.5,) := >=1.5.0
+//   - a single version within brackets is an exact match, e.g. [1.2] := =1.2.0
+//   - a bare version is a minimum, e.g. 1.0 := >=1.0.0, following NuGet.
+//     Maven treats bare versions as soft requirement that any version satisfies.
+//   - intervals separated by comma are joined by logical OR
+//   - missing minor and patch numbers are filled with 0
+//
+// The returned Constraint keeps the input as its String representation.
+func ParseInterval(data string) (Constraint, error) {
+	p := intervalParser{data: data}
+	trimmed := strings.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0:
+		return nil, errorAtOffset(data, 0, ErrEmptyInput, "empty")
+
+	case trimmed[0] != '[' && trimmed[0] != '(':
+		// bare versions are minimums.
+		v, err := p.version(trimmed, strings.Index(data, trimmed))
+		if err != nil {
+			return nil, err
+		}
+		return &originalInputConstraint{
+			Constraint: &Range{Min: v, MaxUnbounded: true},
+			original:   data,
+		}, nil
+	}
+
+	var intervals or
+	for {
+		r, err := p.interval()
+		if err != nil {
+			return nil, err
+		}
+		intervals = append(intervals, r)
+
+		p.skipSpace()
+		if p.offset == len(data) {
+			break
+		}
+		if data[p.offset] != ',' {
+			return nil, errorAtOffset(data, p.offset, ErrSyntax, "unexpected %q after interval", p.rune())
+		}
+		p.offset++
+		p.skipSpace()
+	}
+
+	var c Constraint = intervals
+	if len(intervals) == 1 {
+		c = intervals[0]
+	}
+	return &originalInputConstraint{
+		Constraint: c,
+		original:   data,
+	}, nil
+}
+
+type intervalParser struct {
+	data   string
+	offset int // byte offset within data
+}
+
+// interval parses a single interval starting at the current offset.
+func (p *intervalParser) interval() (*Range, error) {
+	start := p.offset
+	if start == len(p.data) {
+		return nil, errorAtOffset(p.data, start, ErrMissingSegment, "missing interval after comma")
+	}
+	open := p.data[start]
+	if open != '[' && open != '(' {
+		return nil, errorAtOffset(p.data, start, ErrSyntax, "expected [ or ( instead of %q", p.rune())
+	}
+	end := strings.IndexAny(p.data[start:], "])")
+	if end < 0 {
+		return nil, errorAtOffset(p.data, len(p.data), ErrSyntax, "missing ] or ) to close interval")
+	}
+	end += start
+	closing := p.data[end]
+	p.offset = end + 1
+
+	text := p.data[start : end+1]
+	lowerText, upperText, hasComma := strings.Cut(p.data[start+1:end], ",")
+	if strings.Contains(upperText, ",") {
+		return nil, errorAtOffset(p.data, start, ErrSyntax, "too many bounds in %s", text)
+	}
+
+	r := &Range{}
+	if !hasComma {
+		// exact version: [1.2]
+		if open != '[' || closing != ']' {
+			return nil, errorAtOffset(p.data, start, ErrSyntax, "single version %s must be enclosed in [ ]", text)
+		}
+		v, err := p.version(lowerText, start+1)
+		if err != nil {
+			return nil, err
+		}
+		r.Min, r.Max = v, v
+		return r, nil
+	}
+
+	if len(strings.TrimSpace(lowerText)) == 0 {
+		r.MinUnbounded = true
+	} else {
+		v, err := p.version(lowerText, start+1)
+		if err != nil {
+			return nil, err
+		}
+		r.Min, r.MinExclusive = v, open == '('
+	}
+	if len(strings.TrimSpace(upperText)) == 0 {
+		r.MaxUnbounded = true
+	} else {
+		v, err := p.version(upperText, start+1+len(lowerText)+len(","))
+		if err != nil {
+			return nil, err
+		}
+		r.Max, r.MaxExclusive = v, closing == ')'
+	}
+
+	if r.isEmpty() {
+		return nil, errorAtOffset(p.data, start, ErrOverConstrained, "%s allows no version", text)
+	}
+	return r, nil
+}
+
+// version parses a bound starting at the given byte offset.
+func (p *intervalParser) version(s string, offset int) (Version, error) {
+	tok := trimToken(dialectToken{text: s, offset: offset})
+	if len(tok.text) == 0 {
+		return Version{}, errorAtOffset(p.data, tok.offset, ErrMissingSegment, "missing version")
+	}
+	v, err := NewVersion(tok.text, intervalVersionOptions)
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		// report positions within the whole input.
+		perr.Pos += utf8.RuneCountInString(p.data[:tok.offset])
+		perr.Input = p.data
+	}
+	return v, err
+}
+
+func (p *intervalParser) skipSpace() {
+	for p.offset < len(p.data) && (p.data[p.offset] == ' ' || p.data[p.offset] == '\t') {
+		p.offset++
+	}
+}
+
+// rune returns the character at the current offset.
+func (p *intervalParser) rune() string {
+	r, _ := utf8.DecodeRuneInString(p.data[p.offset:])
+	return string(r)
+}
+
+// FormatInterval prints the Constraint in the interval notation of Maven and NuGet,
+// e.g. [1.2.0,1.3.0),[1.5.0,).
+// Inclusive wildcard upper bounds are printed as exclusive bound of the next version,
+// e.g. <=1.2.x as 1.3.0).
+// Constraints that allow no release, e.g. >=1.0.0-0 <1.0.0, or bounds with the <max> number
+// return ErrNotExpressible.
+//
+// Note that NuGet does not support multiple intervals.
+func FormatInterval(c Constraint) (string, error) {
+	return Format(c, StyleInterval)
+}
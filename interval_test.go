@@ -0,0 +1,152 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		interval string
+		matches  []string
+		rejected []string
+	}{
+		{
+			input:    "[1.0,2.0)",
+			interval: "[1.0.0,2.0.0)",
+			matches:  []string{"1.0.0", "1.9.9", "2.0.0-rc.1"},
+			rejected: []string{"0.9.0", "2.0.0"},
+		},
+		{
+			input:    "(,1.0]",
+			interval: "(,1.0.0]",
+			matches:  []string{"0.0.1", "1.0.0"},
+			rejected: []string{"1.0.1"},
+		},
+		{
+			input:    "(1.0,2.0)",
+			interval: "(1.0.0,2.0.0)",
+			matches:  []string{"1.0.1"},
+			rejected: []string{"1.0.0", "2.0.0"},
+		},
+		{
+			input:    "[1.5,)",
+			interval: "[1.5.0,)",
+			matches:  []string{"1.5.0", "9.0.0"},
+			rejected: []string{"1.4.9"},
+		},
+		{
+			input:    "[1.2]",
+			interval: "[1.2.0]",
+			matches:  []string{"1.2.0"},
+			rejected: []string{"1.2.1"},
+		},
+		{
+			input:    "1.0",
+			interval: "[1.0.0,)",
+			matches:  []string{"1.0.0", "2.0.0"},
+			rejected: []string{"0.9.0"},
+		},
+		{
+			input:    "[1.2,1.3],[1.5,)",
+			interval: "[1.2.0,1.3.0],[1.5.0,)",
+			matches:  []string{"1.2.0", "1.3.0", "1.5.0"},
+			rejected: []string{"1.3.1", "1.4.0"},
+		},
+		{
+			input:    "(,1.0], [1.2, )",
+			interval: "(,1.0.0],[1.2.0,)",
+			matches:  []string{"1.0.0", "1.2.0"},
+			rejected: []string{"1.1.0"},
+		},
+		{
+			input:    "[1.0.0-rc.1, 1.0.0]",
+			interval: "[1.0.0-rc.1,1.0.0]",
+			matches:  []string{"1.0.0-rc.2", "1.0.0"},
+			rejected: []string{"1.0.0-beta.1"},
+		},
+		{
+			input:    "(,)",
+			interval: "(,)",
+			matches:  []string{"0.0.0", "1.0.0-rc.1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			c, err := ParseInterval(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.input, c.String())
+
+			s, err := FormatInterval(c)
+			require.NoError(t, err)
+			assert.Equal(t, test.interval, s)
+
+			for _, v := range test.matches {
+				assert.True(t, c.Check(MustNewVersion(v)), v)
+			}
+			for _, v := range test.rejected {
+				assert.False(t, c.Check(MustNewVersion(v)), v)
+			}
+		})
+	}
+}
+
+func TestParseInterval_error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input       string
+		kind        error
+		expectedErr string
+	}{
+		{input: "", kind: ErrEmptyInput, expectedErr: "col 1: empty"},
+		{input: "[1.0,2.0", kind: ErrSyntax, expectedErr: "col 9: missing ] or ) to close interval"},
+		{input: "[1.0,2.0),", kind: ErrMissingSegment, expectedErr: "col 11: missing interval after comma"},
+		{input: "[1.0,2.0) [3.0,)", kind: ErrSyntax, expectedErr: `col 11: unexpected "[" after interval`},
+		{input: "[1.0,2.0),3.0", kind: ErrSyntax, expectedErr: `col 11: expected [ or ( instead of "3"`},
+		{input: "[1.0,2.0,3.0]", kind: ErrSyntax, expectedErr: "col 1: too many bounds in [1.0,2.0,3.0]"},
+		{input: "(1.0)", kind: ErrSyntax, expectedErr: "col 1: single version (1.0) must be enclosed in [ ]"},
+		{input: "[]", kind: ErrMissingSegment, expectedErr: "col 2: missing version"},
+		{input: "[2.0,1.0]", kind: ErrOverConstrained, expectedErr: "col 1: [2.0,1.0] allows no version"},
+		{input: "(1.0,1.0]", kind: ErrOverConstrained, expectedErr: "col 1: (1.0,1.0] allows no version"},
+		{input: "[1.0, 2.a)", kind: ErrInvalidCharacter, expectedErr: "col 8: starts with non-positive integer 'a'"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseInterval(test.input)
+			require.EqualError(t, err, test.expectedErr)
+			require.ErrorIs(t, err, test.kind)
+		})
+	}
+}
+
+func TestFormatInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "^1.2.3", expected: "[1.2.3,2.0.0)"},
+		{input: ">1.2.3 <2", expected: "(1.2.3,2.0.0)"},
+		{input: "<=1.2.3", expected: "(,1.2.3]"},
+		{input: "=1.2.3", expected: "[1.2.3]"},
+		{input: "~1.2 || >=2", expected: "[1.2.0,1.3.0),[2.0.0,)"},
+		{input: "!=1.2.3", expected: "(,1.2.3),(1.2.3,)"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			s, err := FormatInterval(MustNewConstraint(test.input))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, s)
+		})
+	}
+
+	_, err := FormatInterval(RangeSet{})
+	require.ErrorIs(t, err, ErrNotExpressible)
+}
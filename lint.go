@@ -0,0 +1,242 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"pkg.package-operator.run/semver/internal"
+)
+
+// LintRule identifies the kind of issue reported by Lint.
+type LintRule string
+
+// Rules checked by Lint.
+const (
+	// LintSyntax reports constraints that fail to parse.
+	LintSyntax LintRule = "syntax"
+	// LintUnsatisfiable reports || branches that allow no version.
+	LintUnsatisfiable LintRule = "unsatisfiable"
+	// LintShadowedBranch reports || branches fully contained in other branches.
+	LintShadowedBranch LintRule = "shadowed-branch"
+	// LintRedundantClause reports clauses that do not restrict the other clauses of their branch.
+	LintRedundantClause LintRule = "redundant-clause"
+	// LintNeedlessExclusion reports != clauses outside every range of their branch.
+	LintNeedlessExclusion LintRule = "needless-exclusion"
+	// LintMatchAll reports wildcards that expand to every version.
+	LintMatchAll LintRule = "match-all"
+	// LintCaretZero reports ^0.0 clauses, which only match patch releases.
+	LintCaretZero LintRule = "caret-zero"
+	// LintMaxNumber reports version numbers that touch <max>.
+	LintMaxNumber LintRule = "max-number"
+)
+
+// Finding is an issue reported by Lint.
+type Finding struct {
+	// Rule that reported the finding.
+	Rule LintRule
+	// Span of the input the finding is about.
+	Span Span
+	// Message describes the issue.
+	Message string
+	// Replacement for the Span, an empty Replacement removes the Span.
+	Replacement string
+	// Suggestion is the input with the Replacement applied.
+	// Empty if there is no suggested rewrite, e.g. for syntax errors.
+	Suggestion string
+}
+
+// Lint reports problems within a constraint, without changing how it is parsed.
+// Unlike NewConstraint, Lint does not stop at redundant or over-constrained clauses,
+// but reports them with a suggested rewrite.
+// Findings are ordered by their position in the input.
+func Lint(input string) []Finding {
+	l := linter{input: input}
+	ast, err := ParseAST(input, RecoverErrors{})
+	var perrs ParseErrorList
+	if errors.As(err, &perrs) {
+		for _, perr := range perrs {
+			start := columnOffset(input, perr.Pos)
+			end := start
+			if _, w := utf8.DecodeRuneInString(input[start:]); w > 0 {
+				end += w
+			}
+			if errors.Is(perr, ErrOverflow) {
+				// span the whole number.
+				for end < len(input) && internal.IsDigit(rune(input[end])) {
+					end++
+				}
+			}
+			l.report(LintSyntax, Span{Start: start, End: end}, perr.Msg, "", false)
+		}
+	}
+
+	groups := make([]RangeSet, len(ast.Or))
+	groupSpans := make([]Span, len(ast.Or))
+	for i := range ast.Or {
+		groups[i] = l.lintGroup(&ast.Or[i])
+		groupSpans[i] = ast.Or[i].Span
+	}
+
+	// branches are checked from last to first,
+	// so only the later one of two equal branches is reported.
+	shadowed := make([]bool, len(groups))
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i].IsEmpty() {
+			l.report(LintUnsatisfiable, listRemoval(groupSpans, i),
+				fmt.Sprintf("%s allows no version", ast.Or[i].String()), "", len(groups) > 1)
+			shadowed[i] = true
+			continue
+		}
+		var others RangeSet
+		for j := range groups {
+			if j != i && !shadowed[j] {
+				others = Union(others, groups[j])
+			}
+		}
+		if others.Contains(groups[i]) {
+			l.report(LintShadowedBranch, listRemoval(groupSpans, i),
+				fmt.Sprintf("%s is already allowed by other branches", ast.Or[i].String()), "", true)
+			shadowed[i] = true
+		}
+	}
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		return l.findings[i].Span.Start < l.findings[j].Span.Start
+	})
+	return l.findings
+}
+
+type linter struct {
+	input    string
+	findings []Finding
+}
+
+// report adds a finding, fix controls whether the replacement is a suggested rewrite.
+func (l *linter) report(rule LintRule, span Span, msg, replacement string, fix bool) {
+	f := Finding{
+		Rule:    rule,
+		Span:    span,
+		Message: msg,
+	}
+	if fix {
+		f.Replacement = replacement
+		f.Suggestion = l.input[:span.Start] + replacement + l.input[span.End:]
+	}
+	l.findings = append(l.findings, f)
+}
+
+// lintGroup checks the clauses of a || branch and returns the versions it allows.
+func (l *linter) lintGroup(g *AndGroup) RangeSet {
+	clauses := make([]RangeSet, len(g.Clauses))
+	clauseSpans := make([]Span, len(g.Clauses))
+	for i := range g.Clauses {
+		clauses[i] = Normalize(lowerClause(&g.Clauses[i]))
+		clauseSpans[i] = g.Clauses[i].Span
+	}
+	for i := range g.Clauses {
+		l.lintClause(&g.Clauses[i], clauses[i], len(g.Clauses) > 1, listRemoval(clauseSpans, i))
+	}
+
+	group := RangeSet{fullRange}
+	for _, c := range clauses {
+		group = Intersect(group, c)
+	}
+	if group.IsEmpty() || len(clauses) < 2 {
+		return group
+	}
+
+	// a clause is redundant, if the other clauses already imply it.
+	redundant := make([]bool, len(clauses))
+	for i := range clauses {
+		others := RangeSet{fullRange}
+		for j := range clauses {
+			if j != i && !redundant[j] {
+				others = Intersect(others, clauses[j])
+			}
+		}
+		if !clauses[i].Contains(others) {
+			continue
+		}
+		redundant[i] = true
+
+		clause := &g.Clauses[i]
+		span := listRemoval(clauseSpans, i)
+		if clause.Op == OpNotEqual {
+			l.report(LintNeedlessExclusion, span,
+				fmt.Sprintf("%s excludes no version allowed by the other clauses", clause.String()), "", true)
+			continue
+		}
+		if isMatchAll(clauses[i]) && clause.Version.HasWildcard() {
+			// already reported as match-all.
+			continue
+		}
+		l.report(LintRedundantClause, span,
+			fmt.Sprintf("%s is implied by the other clauses", clause.String()), "", true)
+	}
+	return group
+}
+
+// lintClause checks a single clause.
+// removal is the span to remove the clause from its branch.
+func (l *linter) lintClause(clause *Clause, allowed RangeSet, hasOthers bool, removal Span) {
+	for _, v := range []*PartialVersion{&clause.Version, &clause.Upper} {
+		// wildcards only expand to <max> within the upper bound of hyphen ranges.
+		upper := v == &clause.Upper
+		for _, s := range v.Segments {
+			if !s.Wildcard && s.Number == maxUint64 {
+				l.report(LintMaxNumber, s.Span,
+					fmt.Sprintf("%d is the <max> number reserved for wildcards and may overflow", s.Number), "x", upper)
+			}
+		}
+	}
+
+	if (clause.Version.HasWildcard() || clause.Upper.HasWildcard()) && isMatchAll(allowed) {
+		if hasOthers {
+			// redundant checks of the branch provide the removal.
+			l.report(LintMatchAll, removal,
+				fmt.Sprintf("%s matches every version", clause.String()), "", true)
+		} else {
+			l.report(LintMatchAll, clause.Span,
+				fmt.Sprintf("%s matches every version", clause.String()), ">=0.0.0", true)
+		}
+	}
+
+	if clause.Op == OpCaret {
+		lo, _ := clause.Version.lower(0)
+		if lo.Major == 0 && lo.Minor == 0 {
+			lo = Version{Patch: lo.Patch, PreRelease: lo.PreRelease}
+			l.report(LintCaretZero, clause.Span,
+				fmt.Sprintf("%s only matches 0.0.x patch releases", clause.String()),
+				fmt.Sprintf(">=%s <0.1.0", lo.String()), true)
+		}
+	}
+}
+
+// isMatchAll returns true if the RangeSet allows every release version.
+func isMatchAll(s RangeSet) bool {
+	return s.Contains(&Range{MaxUnbounded: true})
+}
+
+// listRemoval returns the span to remove the i-th element of a list,
+// including the separator to its neighbor.
+func listRemoval(spans []Span, i int) Span {
+	switch {
+	case i > 0:
+		return Span{Start: spans[i-1].End, End: spans[i].End}
+	case len(spans) > 1:
+		return Span{Start: spans[0].Start, End: spans[1].Start}
+	}
+	return spans[i]
+}
+
+// columnOffset converts a character column starting at 1 into a byte offset.
+func columnOffset(s string, col int) int {
+	offset := 0
+	for c := 1; c < col && offset < len(s); c++ {
+		_, w := utf8.DecodeRuneInString(s[offset:])
+		offset += w
+	}
+	return offset
+}
@@ -0,0 +1,118 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected []Finding
+	}{
+		{input: `>=1.2 <2 || ~3.1`},
+		{
+			input: `>=1 >=2`,
+			expected: []Finding{{
+				Rule: LintRedundantClause, Span: Span{Start: 0, End: 4},
+				Message:    ">=1 is implied by the other clauses",
+				Suggestion: ">=2",
+			}},
+		},
+		{
+			input: `1 - 2 || 1.5 - 1.7`,
+			expected: []Finding{{
+				Rule: LintShadowedBranch, Span: Span{Start: 5, End: 18},
+				Message:    "1.5 - 1.7 is already allowed by other branches",
+				Suggestion: "1 - 2",
+			}},
+		},
+		{
+			input: `^1 || ^1`,
+			expected: []Finding{{
+				Rule: LintShadowedBranch, Span: Span{Start: 2, End: 8},
+				Message:    "^1 is already allowed by other branches",
+				Suggestion: "^1",
+			}},
+		},
+		{
+			input: `>=1 <2 !=3.0.0`,
+			expected: []Finding{{
+				Rule: LintNeedlessExclusion, Span: Span{Start: 6, End: 14},
+				Message:    "!=3.0.0 excludes no version allowed by the other clauses",
+				Suggestion: ">=1 <2",
+			}},
+		},
+		{
+			input: `x - x`,
+			expected: []Finding{{
+				Rule: LintMatchAll, Span: Span{Start: 0, End: 5},
+				Message:     "x - x matches every version",
+				Replacement: ">=0.0.0",
+				Suggestion:  ">=0.0.0",
+			}},
+		},
+		{
+			input: `>=1 && x - x`,
+			expected: []Finding{{
+				Rule: LintMatchAll, Span: Span{Start: 3, End: 12},
+				Message:    "x - x matches every version",
+				Suggestion: ">=1",
+			}},
+		},
+		{
+			input: `^0.0.3`,
+			expected: []Finding{{
+				Rule: LintCaretZero, Span: Span{Start: 0, End: 6},
+				Message:     "^0.0.3 only matches 0.0.x patch releases",
+				Replacement: ">=0.0.3 <0.1.0",
+				Suggestion:  ">=0.0.3 <0.1.0",
+			}},
+		},
+		{
+			input: `1 - 2.18446744073709551615`,
+			expected: []Finding{{
+				Rule: LintMaxNumber, Span: Span{Start: 6, End: 26},
+				Message:     "18446744073709551615 is the <max> number reserved for wildcards and may overflow",
+				Replacement: "x",
+				Suggestion:  "1 - 2.x",
+			}},
+		},
+		{
+			input: `>18446744073709551615`,
+			expected: []Finding{{
+				Rule: LintMaxNumber, Span: Span{Start: 1, End: 21},
+				Message: "18446744073709551615 is the <max> number reserved for wildcards and may overflow",
+			}},
+		},
+		{
+			input: `>=18446744073709551616 || ~1`,
+			expected: []Finding{{
+				Rule: LintSyntax, Span: Span{Start: 2, End: 22},
+				Message: "number 18446744073709551616 too large",
+			}},
+		},
+		{
+			input: `=1.2.3.4 || >=2 <1 || ~1`,
+			expected: []Finding{
+				{
+					Rule: LintSyntax, Span: Span{Start: 6, End: 7},
+					Message: "found 3rd dot when parsing semver",
+				},
+				{
+					Rule: LintUnsatisfiable, Span: Span{Start: 12, End: 22},
+					Message:    ">=2 && <1 allows no version",
+					Suggestion: "=1.2.3.4 || ~1",
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, Lint(test.input))
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package semver
+
+import (
+	"strings"
+)
+
+// ParseNPM parses a range in the syntax of npm's node-semver into a Constraint,
+// e.g. "1.x || >=2.5.0 || 5.0.0 - 7.2.3" or "^0.0.3".
+//
+// Differences to NewConstraint:
+//   - comparators are separated by whitespace, && and , are not supported
+//   - "", "*", "x" and "latest" match any version
+//   - versions may be prefixed with v or =, e.g. =v1.2.3
+//   - ~ and ^ follow node-semver, e.g. ^0.0.3 := >=0.0.3 <0.0.4-0 and ~1 := >=1.0.0 <2.0.0-0
+//   - the upper bound of hyphen ranges with partial versions is exclusive, e.g. 1 - 2 := >=1.0.0 <3.0.0-0
+//   - pre-releases only match, if a comparator within the same || branch
+//     has a pre-release with the same major.minor.patch
+//
+// The returned Constraint keeps the input as its String representation.
+func ParseNPM(data string) (Constraint, error) {
+	var branches or
+	offset := 0
+	for set := range strings.SplitSeq(data, "||") {
+		c, err := parseNPMSet(data, set, offset)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, c)
+		offset += len(set) + len("||")
+	}
+
+	var c Constraint
+	if len(branches) == 1 {
+		c = branches[0]
+	} else {
+		c = branches
+	}
+	return &originalInputConstraint{
+		Constraint: c,
+		original:   data,
+	}, nil
+}
+
+// parseNPMSet parses the space separated comparators of a single || branch.
+func parseNPMSet(data, set string, offset int) (Constraint, error) {
+	tokens := splitNPMSet(set, offset)
+	if len(tokens) == 0 {
+		// empty sets match any version.
+		tokens = []dialectToken{{text: "*", offset: offset}}
+	}
+
+	r := fullRange
+	var preReleases []Version
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok.text == "-" {
+			return nil, errorAtOffset(data, tok.offset, ErrMissingSegment, "hyphen range without lower bound")
+		}
+
+		if i+1 < len(tokens) && tokens[i+1].text == "-" {
+			// hyphen range
+			if i+2 >= len(tokens) {
+				return nil, errorAtOffset(data, tokens[i+1].offset, ErrMissingSegment, "hyphen range without upper bound")
+			}
+			from, err := npmDialect.parseLooseVersion(data, tok)
+			if err != nil {
+				return nil, err
+			}
+			to, err := npmDialect.parseLooseVersion(data, tokens[i+2])
+			if err != nil {
+				return nil, err
+			}
+			hr, err := npmHyphenRange(data, from, to, tokens[i+2])
+			if err != nil {
+				return nil, err
+			}
+			r.narrow(hr)
+			preReleases = append(preReleases, from.preReleases()...)
+			preReleases = append(preReleases, to.preReleases()...)
+			i += 2
+			continue
+		}
+
+		op, rest := cutOperator(tok.text, npmOperators)
+		p, err := parseNPMVersion(data, dialectToken{text: rest, offset: tok.offset + len(op)})
+		if err != nil {
+			return nil, err
+		}
+		cr, err := npmDialect.comparatorRange(data, op, p, tok)
+		if err != nil {
+			return nil, err
+		}
+		r.narrow(cr)
+		preReleases = append(preReleases, p.preReleases()...)
+	}
+
+	return &preReleaseConstraint{
+		Constraint:  &r,
+		preReleases: preReleases,
+	}, nil
+}
+
+// splitNPMSet splits a set on whitespace,
+// joining operators with the version following them, e.g. ">= 1.2".
+func splitNPMSet(set string, offset int) []dialectToken {
+	var tokens []dialectToken
+	start := -1
+	for i := 0; i <= len(set); i++ {
+		if i < len(set) && set[i] != ' ' && set[i] != '\t' {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start < 0 {
+			continue
+		}
+		tok := dialectToken{text: set[start:i], offset: offset + start}
+		start = -1
+		if n := len(tokens); n > 0 && isNPMOperator(tokens[n-1].text) {
+			tokens[n-1].text += tok.text
+			continue
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+var npmOperators = []string{"~>", "~", "^", ">=", "<=", ">", "<", "="}
+
+func isNPMOperator(s string) bool {
+	op, rest := cutOperator(s, npmOperators)
+	return len(op) > 0 && len(rest) == 0
+}
+
+// parseNPMVersion parses the version of a comparator, latest matches any version.
+func parseNPMVersion(data string, tok dialectToken) (looseVersion, error) {
+	if tok.text == "latest" {
+		return looseVersion{}, nil
+	}
+	return npmDialect.parseLooseVersion(data, tok)
+}
+
+// npmHyphenRange desugars a hyphen range into a Range,
+// e.g. 1.2 - 2.3.4 := >=1.2.0 <=2.3.4 and 1.2.3 - 2 := >=1.2.3 <3.0.0-0.
+func npmHyphenRange(data string, from, to looseVersion, toTok dialectToken) (Range, error) {
+	r := fullRange
+	if from.n > 0 {
+		r.Min, r.MinUnbounded = from.version(), false
+	}
+	switch to.n {
+	case 0:
+	case 3:
+		r.Max, r.MaxUnbounded = to.version(), false
+	default:
+		upper, err := npmDialect.upper(data, toTok, to, to.n-1)
+		if err != nil {
+			return r, err
+		}
+		r.Max, r.MaxExclusive, r.MaxUnbounded = upper, true, false
+	}
+	return r, nil
+}
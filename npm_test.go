@@ -0,0 +1,247 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from node-semver's range-include and range-exclude fixtures.
+func TestParseNPM(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		version  string
+		expected bool
+	}{
+		{"1.0.0 - 2.0.0", "1.2.3", true},
+		{"^1.2.3+build", "1.2.3", true},
+		{"^1.2.3+build", "1.3.0", true},
+		{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "1.2.3", true},
+		{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "1.2.3-pre.2", true},
+		{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "2.4.3-alpha", true},
+		{"1.2.3+asdf - 2.4.3+asdf", "1.2.3", true},
+		{"1.0.0", "1.0.0", true},
+		{">=*", "0.2.4", true},
+		{"", "1.0.0", true},
+		{"*", "1.2.3", true},
+		{">=1.0.0", "1.0.0", true},
+		{">=1.0.0", "1.1.0", true},
+		{">1.0.0", "1.0.1", true},
+		{"<=2.0.0", "2.0.0", true},
+		{"<=2.0.0", "0.2.9", true},
+		{"<2.0.0", "1.9999.9999", true},
+		{">= 1.0.0", "1.0.0", true},
+		{">=  1.0.0", "1.0.1", true},
+		{"> 1.0.0", "1.0.1", true},
+		{"<=   2.0.0", "2.0.0", true},
+		{"0.1.20 || 1.2.4", "1.2.4", true},
+		{">=0.2.3 || <0.0.1", "0.0.0", true},
+		{">=0.2.3 || <0.0.1", "0.2.3", true},
+		{"||", "1.3.4", true},
+		{"2.x.x", "2.1.3", true},
+		{"1.2.x", "1.2.3", true},
+		{"1.2.x || 2.x", "2.1.3", true},
+		{"1.2.x || 2.x", "1.2.3", true},
+		{"x", "1.2.3", true},
+		{"2.*.*", "2.1.3", true},
+		{"1.2.*", "1.2.3", true},
+		{"2", "2.1.2", true},
+		{"2.3", "2.3.1", true},
+		{"~0.0.1", "0.0.1", true},
+		{"~0.0.1", "0.0.2", true},
+		{"~x", "0.0.9", true},
+		{"~2", "2.0.9", true},
+		{"~2.4", "2.4.0", true},
+		{"~2.4", "2.4.5", true},
+		{"~>3.2.1", "3.2.2", true},
+		{"~1", "1.2.3", true},
+		{"~>1", "1.2.3", true},
+		{"~> 1", "1.2.3", true},
+		{"~1.0", "1.0.2", true},
+		{"~ 1.0", "1.0.2", true},
+		{">=1", "1.0.0", true},
+		{">= 1", "1.0.0", true},
+		{"<1.2", "1.1.1", true},
+		{"< 1.2", "1.1.1", true},
+		{"~v0.5.4-pre", "0.5.5", true},
+		{"~v0.5.4-pre", "0.5.4", true},
+		{"=0.7.x", "0.7.2", true},
+		{"<=0.7.x", "0.7.2", true},
+		{">=0.7.x", "0.7.2", true},
+		{"<=0.7.x", "0.6.2", true},
+		{"~1.2.1 >=1.2.3", "1.2.3", true},
+		{"~1.2.1 =1.2.3", "1.2.3", true},
+		{"~1.2.1 1.2.3", "1.2.3", true},
+		{"~1.2.1 >=1.2.3 1.2.3", "1.2.3", true},
+		{">=1.2.1 1.2.3", "1.2.3", true},
+		{"1.2.3 >=1.2.1", "1.2.3", true},
+		{">=1.2.3 >=1.2.1", "1.2.3", true},
+		{">=1.2.1 >=1.2.3", "1.2.3", true},
+		{">=1.2", "1.2.8", true},
+		{"^1.2.3", "1.8.1", true},
+		{"^0.1.2", "0.1.2", true},
+		{"^0.1", "0.1.2", true},
+		{"^0.0.1", "0.0.1", true},
+		{"^1.2", "1.4.2", true},
+		{"^1.2 ^1", "1.4.2", true},
+		{"^1.2.3-alpha", "1.2.3-pre", true},
+		{"^1.2.0-alpha", "1.2.0-pre", true},
+		{"^0.0.1-alpha", "0.0.1-beta", true},
+		{"^0.0.1-alpha", "0.0.1", true},
+		{"^0.1.1-alpha", "0.1.1-beta", true},
+		{"^x", "1.2.3", true},
+		{"x - 1.0.0", "0.9.7", true},
+		{"x - 1.x", "0.9.7", true},
+		{"1.0.0 - x", "1.9.7", true},
+		{"1.x - x", "1.9.7", true},
+		{"<=7.x", "7.9.9", true},
+		{"latest", "1.2.3", true},
+
+		{"1.0.0 - 2.0.0", "2.2.3", false},
+		{"1.2.3+asdf - 2.4.3+asdf", "1.2.3-pre.2", false},
+		{"1.2.3+asdf - 2.4.3+asdf", "2.4.3-alpha", false},
+		{"^1.2.3+build", "2.0.0", false},
+		{"^1.2.3+build", "1.2.0", false},
+		{"^1.2.3", "1.2.3-pre", false},
+		{"^1.2", "1.2.0-pre", false},
+		{">1.2", "1.3.0-beta", false},
+		{"<=1.2.3", "1.2.3-beta", false},
+		{"^1.2.3", "1.2.3-beta", false},
+		{"=0.7.x", "0.7.0-asdf", false},
+		{">=0.7.x", "0.7.0-asdf", false},
+		{"1.0.0", "1.0.1", false},
+		{">=1.0.0", "0.0.0", false},
+		{">=1.0.0", "0.0.1", false},
+		{">=1.0.0", "0.1.0", false},
+		{">1.0.0", "0.0.1", false},
+		{">1.0.0", "0.1.0", false},
+		{"<=2.0.0", "3.0.0", false},
+		{"<=2.0.0", "2.9999.9999", false},
+		{"<=2.0.0", "2.2.9", false},
+		{"<2.0.0", "2.9999.9999", false},
+		{"<2.0.0", "2.2.9", false},
+		{">=0.1.97", "v0.1.93", false},
+		{">=0.1.97", "0.1.93", false},
+		{"0.1.20 || 1.2.4", "1.2.3", false},
+		{">=0.2.3 || <0.0.1", "0.0.3", false},
+		{">=0.2.3 || <0.0.1", "0.2.2", false},
+		{"2.x.x", "1.1.3", false},
+		{"2.x.x", "3.1.3", false},
+		{"1.2.x", "1.3.3", false},
+		{"1.2.x || 2.x", "3.1.3", false},
+		{"1.2.x || 2.x", "1.1.3", false},
+		{"2.*.*", "1.1.3", false},
+		{"2.*.*", "3.1.3", false},
+		{"1.2.*", "1.3.3", false},
+		{"2", "1.1.2", false},
+		{"2.3", "2.4.1", false},
+		{"~0.0.1", "0.1.0-alpha", false},
+		{"~0.0.1", "0.1.0", false},
+		{"~2.4", "2.5.0", false},
+		{"~2.4", "2.3.9", false},
+		{"~>3.2.1", "3.3.2", false},
+		{"~>3.2.1", "3.2.0", false},
+		{"~1", "0.2.3", false},
+		{"~>1", "2.2.3", false},
+		{"~1.0", "1.1.0", false},
+		{"<1", "1.0.0", false},
+		{">=1.2", "1.1.1", false},
+		{"1", "2.0.0beta", false},
+		{"~v0.5.4-beta", "0.5.4-alpha", false},
+		{"=0.7.x", "0.8.2", false},
+		{">=0.7.x", "0.6.2", false},
+		{"<0.7.x", "0.7.2", false},
+		{"<1.2.3", "1.2.3-beta", false},
+		{"=1.2.3", "1.2.3-beta", false},
+		{">1.2", "1.2.8", false},
+		{"^0.0.1", "0.0.2-alpha", false},
+		{"^0.0.1", "0.0.2", false},
+		{"^1.2.3", "2.0.0-alpha", false},
+		{"^1.2.3", "1.2.2", false},
+		{"^1.2", "1.1.9", false},
+		{"*", "v1.2.3-foo", false},
+		{"^1.0.0", "2.0.0-rc1", false},
+		{"^1.0.0", "1.0.0-rc1", false},
+		{"^1.2.3-rc2", "2.0.0", false},
+		{"^0.1.0-alpha", "0.2.0", false},
+		{">*", "0.0.0", false},
+		{"<*", "0.0.0", false},
+		{"1.0.0 - x", "0.9.7", false},
+		{"1.x - x", "0.9.7", false},
+		{"<=7.x", "8.0.0", false},
+	}
+	for _, test := range tests {
+		t.Run(test.input+" "+test.version, func(t *testing.T) {
+			t.Parallel()
+			c, err := ParseNPM(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.input, c.String())
+
+			v, err := NewVersion(test.version, Lenient)
+			if err != nil {
+				// node-semver does not parse the version either.
+				assert.False(t, test.expected)
+				return
+			}
+			assert.Equal(t, test.expected, c.Check(v))
+		})
+	}
+}
+
+func TestParseNPM_desugar(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.2.3 - 2.3.4", expected: "1.2.3 - 2.3.4"},
+		{input: "1.2 - 2.3.4", expected: "1.2.0 - 2.3.4"},
+		{input: "1.2.3 - 2.3", expected: ">=1.2.3 <2.4.0-0"},
+		{input: "1.2.3 - 2", expected: ">=1.2.3 <3.0.0-0"},
+		{input: "1.x", expected: ">=1.0.0 <2.0.0-0"},
+		{input: "~1.2.3-beta.2", expected: ">=1.2.3-beta.2 <1.3.0-0"},
+		{input: "~0", expected: ">=0.0.0 <1.0.0-0"},
+		{input: "^0.0.3", expected: ">=0.0.3 <0.0.4-0"},
+		{input: "^0.0.x", expected: ">=0.0.0 <0.1.0-0"},
+		{input: "^0.x", expected: ">=0.0.0 <1.0.0-0"},
+		{input: "^1.2.x", expected: ">=1.2.0 <2.0.0-0"},
+		{input: ">1.2", expected: ">=1.3.0"},
+		{input: "<=1.2", expected: "<1.3.0-0"},
+		{input: "<1", expected: "<1.0.0-0"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			c, err := ParseNPM(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, unwrapConstraint(c).String())
+		})
+	}
+}
+
+func TestParseNPM_error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input       string
+		kind        error
+		expectedErr string
+	}{
+		{input: "1.2.3.4", kind: ErrSyntax, expectedErr: `col 1: too many segments in "1.2.3.4"`},
+		{input: ">=1 <a", kind: ErrSyntax, expectedErr: `col 6: invalid version "a"`},
+		{input: "1 || - 2", kind: ErrMissingSegment, expectedErr: "col 6: hyphen range without lower bound"},
+		{input: "1 -", kind: ErrMissingSegment, expectedErr: "col 3: hyphen range without upper bound"},
+		{input: "^1 >=", kind: ErrMissingSegment, expectedErr: `col 6: missing version in ""`},
+		{input: "1.2-rc.1", kind: ErrMissingSegment, expectedErr: `col 1: pre-release without patch in "1.2-rc.1"`},
+		{input: "~01.2", kind: ErrSyntax, expectedErr: `col 2: invalid version "01.2"`},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseNPM(test.input)
+			require.EqualError(t, err, test.expectedErr)
+			require.ErrorIs(t, err, test.kind)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package semver
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var pep440Operators = []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
+
+// pep440Dialect only provides exclusive upper bounds,
+// PEP 440 operators are desugared by ParsePEP440.
+var pep440Dialect = dialect{preZero: true}
+
+// matches PEP 440 versions, following the regular expression of its appendix,
+// with an additional .* suffix for prefix matches.
+var pep440Regexp = regexp.MustCompile(`(?i)^v?` +
+	`(?:(\d+)!)?` + // epoch
+	`(\d+(?:\.\d+)*)(\.\*)?` + // release and wildcard
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` + // pre-release
+	`(-\d+|[-_.]?(?:post|rev|r)[-_.]?\d*)?` + // post-release
+	`([-_.]?dev[-_.]?\d*)?` + // dev release
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`) // local version label
+
+// ParsePEP440 parses a Python PEP 440 version specifier into a Constraint,
+// e.g. "~=1.4.2, !=1.4.5" or "==2.*".
+//
+// Versions are translated into SemVer:
+//   - missing release segments are 0, e.g. 1.2 := 1.2.0
+//   - pre-releases are normalized to alpha, beta and rc, e.g. 1.2.3a1 := 1.2.3-alpha.1 and 1.2.3c := 1.2.3-rc.0
+//   - epochs other than 0!, post-releases, dev releases, local version labels
+//     and non-zero release segments after the third
+//     can not be expressed in SemVer and return ErrNotExpressible
+//
+// Specifiers follow PEP 440:
+//   - clauses are separated by comma, there is no logical OR
+//   - ~=1.4.2 := >=1.4.2, ==1.4.*
+//   - ==1.4.* and !=1.4.* match by prefix, including pre-releases of the prefix
+//   - <1.5 does not match pre-releases of 1.5.0
+//   - === compares like ==
+//   - pre-releases only match, if a clause other than != has a pre-release
+//
+// The returned Constraint keeps the input as its String representation.
+func ParsePEP440(data string) (Constraint, error) {
+	r := fullRange
+	var (
+		excluded   and
+		preRelease bool
+	)
+	offset := 0
+	for clause := range strings.SplitSeq(data, ",") {
+		tok := trimToken(dialectToken{text: clause, offset: offset})
+		offset += len(clause) + len(",")
+		if len(tok.text) == 0 {
+			// empty clauses are ignored, an empty specifier matches any version.
+			continue
+		}
+
+		op, rest := cutOperator(tok.text, pep440Operators)
+		if len(op) == 0 {
+			return nil, errorAtOffset(data, tok.offset, ErrSyntax, "missing operator before %q", tok.text)
+		}
+		vtok := trimToken(dialectToken{text: rest, offset: tok.offset + len(op)})
+		p, segments, err := parsePEP440Version(data, vtok)
+		if err != nil {
+			return nil, err
+		}
+		if p.wildcard && op != "==" && op != "!=" {
+			return nil, errorAtOffset(data, vtok.offset, ErrSyntax, "wildcard not allowed after %s", op)
+		}
+
+		v := p.padded()
+		var cr Range
+		switch op {
+		case "~=":
+			// ~=1.4.2 := >=1.4.2, ==1.4.*
+			if segments < 2 {
+				return nil, errorAtOffset(data, vtok.offset, ErrMissingSegment, "%s requires at least 2 release segments", op)
+			}
+			upper, err := pep440Dialect.upper(data, vtok, p, min(segments-1, 3)-1)
+			if err != nil {
+				return nil, err
+			}
+			cr = Range{Min: v, Max: upper, MaxExclusive: true}
+
+		case "==", "===", "!=":
+			cr = Range{Min: v, Max: v}
+			if p.wildcard {
+				// ==1.4.* := >=1.4.0-0, <1.5.0-0
+				upper, err := pep440Dialect.upper(data, vtok, p, p.n-1)
+				if err != nil {
+					return nil, err
+				}
+				cr.Min.PreRelease = PreReleaseIdentifierList{PreReleaseIdentifier{}}
+				cr.Max, cr.MaxExclusive = upper, true
+			}
+			if op == "!=" {
+				excluded = append(excluded, not{cr})
+				continue
+			}
+
+		case ">":
+			cr = Range{Min: v, MinExclusive: true, MaxUnbounded: true}
+
+		case ">=":
+			cr = Range{Min: v, MaxUnbounded: true}
+
+		case "<":
+			// <1.5 := <1.5.0-0
+			if len(v.PreRelease) == 0 {
+				v.PreRelease = PreReleaseIdentifierList{PreReleaseIdentifier{}}
+			}
+			cr = Range{Max: v, MaxExclusive: true, MinUnbounded: true}
+
+		case "<=":
+			cr = Range{Max: v, MinUnbounded: true}
+		}
+		r.narrow(cr)
+		preRelease = preRelease || len(p.pre) > 0
+	}
+
+	var c Constraint = &r
+	if len(excluded) > 0 {
+		c = append(and{&r}, excluded...)
+	}
+	if !preRelease {
+		// rejects all pre-releases.
+		c = &preReleaseConstraint{Constraint: c}
+	}
+	return &originalInputConstraint{
+		Constraint: c,
+		original:   data,
+	}, nil
+}
+
+// ParsePEP440Version parses a Python PEP 440 version and translates it into SemVer,
+// e.g. 1.2rc1 => 1.2.0-rc.1.
+// See ParsePEP440 for the versions that can not be expressed in SemVer.
+func ParsePEP440Version(data string) (Version, error) {
+	tok := trimToken(dialectToken{text: data})
+	if len(tok.text) == 0 {
+		return Version{}, errorAtOffset(data, 0, ErrEmptyInput, "empty")
+	}
+	p, _, err := parsePEP440Version(data, tok)
+	if err != nil {
+		return Version{}, err
+	}
+	if p.wildcard {
+		return Version{}, errorAtOffset(data, tok.offset, ErrSyntax, "wildcard in version %q", tok.text)
+	}
+	return p.padded(), nil
+}
+
+// parsePEP440Version parses the version of a specifier clause
+// and returns the number of given release segments.
+func parsePEP440Version(data string, tok dialectToken) (looseVersion, int, error) {
+	var p looseVersion
+	m := pep440Regexp.FindStringSubmatch(tok.text)
+	if m == nil {
+		return p, 0, errorAtOffset(data, tok.offset, ErrSyntax, "invalid version %q", tok.text)
+	}
+	epoch, release, wildcard, preLabel, preNum, post, dev, local := m[1], m[2], m[3], m[4], m[5], m[6], m[7], m[8]
+
+	switch {
+	case len(epoch) > 0 && strings.TrimLeft(epoch, "0") != "":
+		return p, 0, errorAtOffset(data, tok.offset, ErrNotExpressible, "epoch in %q", tok.text)
+	case len(post) > 0:
+		return p, 0, errorAtOffset(data, tok.offset, ErrNotExpressible, "post-release in %q", tok.text)
+	case len(dev) > 0:
+		return p, 0, errorAtOffset(data, tok.offset, ErrNotExpressible, "dev release in %q", tok.text)
+	case len(local) > 0:
+		return p, 0, errorAtOffset(data, tok.offset, ErrNotExpressible, "local version label in %q", tok.text)
+	case len(wildcard) > 0 && len(preLabel) > 0:
+		return p, 0, errorAtOffset(data, tok.offset, ErrSyntax, "wildcard must end the version %q", tok.text)
+	}
+
+	parts := strings.Split(release, ".")
+	for i, part := range parts {
+		num, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return p, 0, errorAtOffset(data, tok.offset, ErrOverflow, "number %s too large", part)
+		}
+		if i >= len(p.nums) {
+			if num != 0 {
+				return p, 0, errorAtOffset(data, tok.offset, ErrNotExpressible, "more than 3 release segments in %q", tok.text)
+			}
+			continue
+		}
+		p.nums[i] = num
+		p.n++
+	}
+	p.wildcard = len(wildcard) > 0
+
+	if len(preLabel) > 0 {
+		label := "rc"
+		switch strings.ToLower(preLabel) {
+		case "a", "alpha":
+			label = "alpha"
+		case "b", "beta":
+			label = "beta"
+		}
+		if len(preNum) == 0 {
+			preNum = "0"
+		}
+		num, err := strconv.ParseUint(preNum, 10, 64)
+		if err != nil {
+			return p, 0, errorAtOffset(data, tok.offset, ErrOverflow, "number %s too large", preNum)
+		}
+		p.pre = PreReleaseIdentifierList{
+			ToPreReleaseIdentifier(label),
+			ToPreReleaseIdentifier(strconv.FormatUint(num, 10)),
+		}
+	}
+	return p, len(parts), nil
+}
+
+// padded returns the version with missing release segments set to 0,
+// keeping the pre-release, e.g. 1.2rc1 := 1.2.0-rc.1.
+func (p looseVersion) padded() Version {
+	return Version{Major: p.nums[0], Minor: p.nums[1], Patch: p.nums[2], PreRelease: p.pre}
+}
@@ -0,0 +1,153 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from the specifier tests of Python's packaging library.
+func TestParsePEP440(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		version  string
+		expected bool
+	}{
+		{"==2", "2.0", true},
+		{"==2.0", "2.0", true},
+		{"==2.0.0", "2.0", true},
+		{"==2.0", "2.0.0", true},
+		{"==2.*", "2.0", true},
+		{"==2.0.*", "2.0.0", true},
+		{"==2.0.*", "2.0.5", true},
+		{"=== 2.0", "2.0", true},
+		{"!=2.1", "2.0", true},
+		{"!=2.1.*", "2.0", true},
+		{"!=2.1.*", "2.0.0", true},
+		{"<=2", "2.0", true},
+		{">=2", "2.0", true},
+		{"<3", "2.0", true},
+		{"<2", "1.0", true},
+		{">1", "2.0", true},
+		{"~=2.2", "2.2", true},
+		{"~=2.2", "2.3", true},
+		{"~=2.1", "2.2", true},
+		{"~=1.2.3", "1.2.3", true},
+		{"~=1.2.3", "1.2.5", true},
+		{"~=2.0.0.0", "2.0.0", true},
+		{">=1.0a1", "1.5b1", true},
+		{"<2.0rc1", "2.0b1", true},
+		{">=1.0a1, <2.0", "1.9rc1", true},
+		{"==1.4.*, >=1.4.0rc1", "1.4.0rc2", true},
+		{"~=1.4.5a4", "1.4.5", true},
+		{"~=1.4.5a4", "1.4.5b1", true},
+		{">=1.0, !=1.3.4.*, <2.0", "1.3.5", true},
+		{"", "1.0", true},
+		{"==V1.0", "1.0", true},
+		{"==2.1", "2.0", false},
+		{"==2.0.*", "2.1", false},
+		{"==2.0.*", "2.0.0rc1", false},
+		{"!=2", "2.0", false},
+		{"!=2.*", "2.0", false},
+		{"!=2.*", "2.0.1", false},
+		{"<2", "2.0", false},
+		{">2", "2.0", false},
+		{"<=2", "2.1", false},
+		{"~=2.2", "3.0", false},
+		{"~=2.2", "2.1", false},
+		{"~=1.2.3", "1.3.0", false},
+		{"~=1.2.3", "1.2.2", false},
+		{">=1", "2.0rc1", false},
+		{"<2", "2.0b1", false},
+		{">=1.0a1, <2.0", "2.0b1", false},
+		{"==1.4.*, >=1.4.0rc1", "1.5.0rc1", false},
+		{"!=1.0a1", "1.0a2", false},
+		{"", "1.0rc1", false},
+	}
+	for _, test := range tests {
+		t.Run(test.input+" "+test.version, func(t *testing.T) {
+			t.Parallel()
+			c, err := ParsePEP440(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.input, c.String())
+
+			v, err := ParsePEP440Version(test.version)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, c.Check(v))
+		})
+	}
+}
+
+func TestParsePEP440_contains(t *testing.T) {
+	t.Parallel()
+	a, err := ParsePEP440("~=1.4")
+	require.NoError(t, err)
+	b, err := ParsePEP440(">=1.4.2, <1.6")
+	require.NoError(t, err)
+
+	assert.True(t, a.Contains(b))
+	assert.False(t, b.Contains(a))
+	assert.True(t, Intersect(b, MustNewConstraint(">=1.6.0")).IsEmpty())
+}
+
+func TestParsePEP440_error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input       string
+		kind        error
+		expectedErr string
+	}{
+		{input: "1.0", kind: ErrSyntax, expectedErr: `col 1: missing operator before "1.0"`},
+		{input: ">=1.0, =>2", kind: ErrSyntax, expectedErr: `col 8: missing operator before "=>2"`},
+		{input: ">=1.0.*", kind: ErrSyntax, expectedErr: "col 3: wildcard not allowed after >="},
+		{input: "==1.0.*rc1", kind: ErrSyntax, expectedErr: `col 3: wildcard must end the version "1.0.*rc1"`},
+		{input: "==1.0-x", kind: ErrSyntax, expectedErr: `col 3: invalid version "1.0-x"`},
+		{input: "~= 1", kind: ErrMissingSegment, expectedErr: "col 4: ~= requires at least 2 release segments"},
+		{input: "==1!2.0", kind: ErrNotExpressible, expectedErr: `col 3: epoch in "1!2.0"`},
+		{input: ">=1.0.post1", kind: ErrNotExpressible, expectedErr: `col 3: post-release in "1.0.post1"`},
+		{input: ">=1.0-1", kind: ErrNotExpressible, expectedErr: `col 3: post-release in "1.0-1"`},
+		{input: "<2.0.dev3", kind: ErrNotExpressible, expectedErr: `col 2: dev release in "2.0.dev3"`},
+		{input: "==1.0+ubuntu.1", kind: ErrNotExpressible, expectedErr: `col 3: local version label in "1.0+ubuntu.1"`},
+		{input: "==1.2.3.4", kind: ErrNotExpressible, expectedErr: `col 3: more than 3 release segments in "1.2.3.4"`},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParsePEP440(test.input)
+			require.EqualError(t, err, test.expectedErr)
+			require.ErrorIs(t, err, test.kind)
+		})
+	}
+}
+
+func TestParsePEP440Version(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.2rc1", expected: "1.2.0-rc.1"},
+		{input: "v1.0a", expected: "1.0.0-alpha.0"},
+		{input: "1.0-preview2", expected: "1.0.0-rc.2"},
+		{input: "1.0.c3", expected: "1.0.0-rc.3"},
+		{input: "1.0B3", expected: "1.0.0-beta.3"},
+		{input: "1.0.0.0", expected: "1.0.0"},
+		{input: "0!1.2", expected: "1.2.0"},
+		{input: " 01.002 ", expected: "1.2.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			v, err := ParsePEP440Version(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v.String())
+		})
+	}
+
+	_, err := ParsePEP440Version("1.*")
+	require.ErrorIs(t, err, ErrSyntax)
+	_, err = ParsePEP440Version("1.0.post1")
+	require.ErrorIs(t, err, ErrNotExpressible)
+}
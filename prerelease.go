@@ -0,0 +1,75 @@
+package semver
+
+// PreReleasePolicy defines how pre-release versions are matched by constraints.
+type PreReleasePolicy int
+
+const (
+	// PreReleaseInclude allows all pre-release versions that fall within a range.
+	// e.g. >=1.2.0 allows 1.3.0-rc.1.
+	PreReleaseInclude PreReleasePolicy = iota
+	// PreReleaseExplicit only allows pre-release versions,
+	// if a bound of the same || branch with the same major.minor.patch
+	// opts into pre-releases. != clauses do not opt in. This matches npm behavior.
+	// e.g. >=1.2.0-rc.1 allows 1.2.0-rc.2, but not 1.3.0-rc.1.
+	PreReleaseExplicit
+)
+
+// ApplyToConstraintOptions implements ConstraintOption.
+func (p PreReleasePolicy) ApplyToConstraintOptions(opts *ConstraintOptions) {
+	opts.PreReleasePolicy = p
+}
+
+func (p PreReleasePolicy) String() string {
+	switch p {
+	case PreReleaseInclude:
+		return "Include"
+	case PreReleaseExplicit:
+		return "Explicit"
+	}
+	return "Unknown"
+}
+
+// preReleaseConstraint implements the PreReleaseExplicit policy.
+// Contains and String are passed through unchanged.
+type preReleaseConstraint struct {
+	Constraint
+	preReleases []Version // versions with pre-release used as bounds
+}
+
+var _ Constraint = (*preReleaseConstraint)(nil)
+
+func (c *preReleaseConstraint) Check(v Version) bool {
+	if len(v.PreRelease) > 0 && !c.optsIn(v) {
+		return false
+	}
+	return c.Constraint.Check(v)
+}
+
+// optsIn returns true if a bound with pre-release shares major.minor.patch with v.
+func (c *preReleaseConstraint) optsIn(v Version) bool {
+	for _, pr := range c.preReleases {
+		if pr.Major == v.Major && pr.Minor == v.Minor && pr.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// policyOf returns the pre-release policy a constraint was parsed with.
+func policyOf(c Constraint) PreReleasePolicy {
+	switch v := c.(type) {
+	case *preReleaseConstraint:
+		return PreReleaseExplicit
+	case *originalInputConstraint:
+		return policyOf(v.Constraint)
+	case Constraints:
+		return policyOf(v.c)
+	case or:
+		for _, b := range v {
+			if policyOf(b) != PreReleaseInclude {
+				return policyOf(b)
+			}
+		}
+	}
+	return PreReleaseInclude
+}
@@ -0,0 +1,64 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreReleasePolicy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		constraint string
+		version    string
+		include    bool
+		explicit   bool
+	}{
+		{constraint: ">=1.2.0", version: "1.3.0", include: true, explicit: true},
+		{constraint: ">=1.2.0", version: "1.3.0-rc.1", include: true, explicit: false},
+		{constraint: "<2.0.0", version: "2.0.0-rc.1", include: true, explicit: false},
+		{constraint: ">=1.2.0-rc.1", version: "1.2.0-rc.2", include: true, explicit: true},
+		{constraint: ">=1.2.0-rc.1", version: "1.2.0-rc.0", include: false, explicit: false},
+		{constraint: ">=1.2.0-rc.1", version: "1.3.0-rc.1", include: true, explicit: false},
+		{constraint: ">=1.2.0-rc.1", version: "1.3.0", include: true, explicit: true},
+		{constraint: "1.0.0-rc.0 - 1.0.0-rc.10", version: "1.0.0-rc.5", include: true, explicit: true},
+		{constraint: "1.0.0-rc.0 - 1.0.0-rc.10", version: "1.0.0-rc.11", include: false, explicit: false},
+		{constraint: "1.0.0-rc.0 - 1.0.0-rc.10", version: "1.0.0", include: false, explicit: false},
+		{constraint: "^1.2.3-beta.2", version: "1.2.3-beta.4", include: true, explicit: true},
+		{constraint: "^1.2.3-beta.2", version: "1.2.4-beta.1", include: true, explicit: false},
+		{constraint: "=1.2.3-rc.1+build.1", version: "1.2.3-rc.1", include: true, explicit: true},
+		{constraint: "!=1.2.3-rc.1", version: "1.2.3-rc.2", include: true, explicit: false},
+		{constraint: ">=1.0.0 !=1.2.3-rc.1", version: "1.2.3-rc.2", include: true, explicit: false},
+		{constraint: ">=1.0.0 !=1.2.3-rc.1", version: "1.2.3", include: true, explicit: true},
+		// every || branch opts in on its own.
+		{constraint: "=1.2.3-rc.1 || >=1.0.0", version: "1.2.3-rc.5", include: true, explicit: false},
+		{constraint: "=1.2.3-rc.1 || >=1.0.0", version: "1.2.3-rc.1", include: true, explicit: true},
+		{constraint: ">=1.2.3-rc.1 <2 || >=3", version: "1.2.3-rc.2", include: true, explicit: true},
+		{constraint: ">=1.2.3-rc.1 <2 || >=3", version: "3.0.0-rc.1", include: false, explicit: false},
+	}
+	for _, test := range tests {
+		t.Run(test.constraint+" "+test.version, func(t *testing.T) {
+			t.Parallel()
+			v := MustNewVersion(test.version)
+
+			include := MustNewConstraint(test.constraint)
+			assert.Equal(t, test.include, include.Check(v), "include")
+
+			explicit, err := NewConstraint(test.constraint, PreReleaseExplicit)
+			require.NoError(t, err)
+			assert.Equal(t, test.explicit, explicit.Check(v), "explicit")
+			assert.Equal(t, test.constraint, explicit.String())
+		})
+	}
+}
+
+func TestPreReleasePolicy_Constraints(t *testing.T) {
+	t.Parallel()
+	c := MustNewConstraints("~1.2", PreReleaseExplicit)
+
+	assert.True(t, c.Check(MustNewVersion("1.2.5")))
+	assert.False(t, c.Check(MustNewVersion("1.2.5-rc.1")))
+	assert.True(t, c.Contains(MustNewConstraint("1.2.1 - 1.2.4")))
+	assert.True(t, Equal(c, MustNewConstraint("~1.2")))
+}
@@ -2,32 +2,82 @@ package semver
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Range represents a min to max version range.
-// Min and Max are inclusive >=/<=
+// Min and Max are inclusive >=/<= by default,
 // e.g. 1.0.0 - 1.1.0 would contain both 1.0.0 and 1.1.0.
+//
+// MinExclusive and MaxExclusive turn the bounds into >/<,
+// e.g. >1.0.0 <1.1.0 contains 1.0.1 and 1.1.0-rc.1, but neither 1.0.0 nor 1.1.0.
+// MinUnbounded and MaxUnbounded remove the bound altogether,
+// the Min or Max version is ignored in this case.
 type Range struct {
 	Min Version
 	Max Version
+
+	MinExclusive bool // Min itself is not part of the range.
+	MaxExclusive bool // Max itself is not part of the range.
+	MinUnbounded bool // Range has no lower bound.
+	MaxUnbounded bool // Range has no upper bound.
 }
 
 func (r *Range) String() string {
-	if r.Min.Same(r.Max) {
+	switch {
+	case r.MinUnbounded && r.MaxUnbounded:
+		return "*"
+	case r.MinUnbounded:
+		return r.upper().String()
+	case r.MaxUnbounded:
+		return r.lower().String()
+	case r.isSingleVersion():
 		return "=" + r.Min.String()
+	case !r.MinExclusive && !r.MaxExclusive:
+		return fmt.Sprintf("%s - %s", r.Min.String(), r.Max.String())
 	}
-	return fmt.Sprintf("%s - %s", r.Min.String(), r.Max.String())
+	return r.lower().String() + " " + r.upper().String()
 }
 
-// Check if the given version is contained in the range.
-func (r *Range) Check(v Version) bool {
-	if v.LessThan(r.Min) {
-		return false
+// Interval prints the range in interval notation,
+// e.g. [1.0.0,2.0.0), (,1.0.0], [1.2.3] or (,).
+// Unlike String, every combination of bounds is printed as is.
+func (r *Range) Interval() string {
+	if r.isSingleVersion() {
+		return "[" + r.Min.String() + "]"
 	}
-	if v.GreaterThan(r.Max) {
-		return false
+
+	var b strings.Builder
+	switch {
+	case r.MinUnbounded:
+		b.WriteString("(")
+	case r.MinExclusive:
+		b.WriteString("(" + r.Min.String())
+	default:
+		b.WriteString("[" + r.Min.String())
+	}
+	b.WriteString(",")
+	switch {
+	case r.MaxUnbounded:
+		b.WriteString(")")
+	case r.MaxExclusive:
+		b.WriteString(r.Max.String() + ")")
+	default:
+		b.WriteString(r.Max.String() + "]")
 	}
-	return true
+	return b.String()
+}
+
+// Same returns true if both Ranges have equivalent bounds,
+// e.g. >1.2.3 and >=1.2.4-0 or <=1.2.x and <1.3.0-0.
+func (r *Range) Same(o Range) bool {
+	return compareLower(r.lower(), o.lower()) == 0 &&
+		compareUpper(r.upper(), o.upper()) == 0
+}
+
+// Check if the given version is contained in the range.
+func (r *Range) Check(v Version) bool {
+	return r.lower().allows(v) && r.upper().allows(v)
 }
 
 // Contains checks if the given constraint fits into this range.
@@ -44,6 +94,20 @@ func rangeContains(r Range, other Constraint) bool {
 	case *originalInputConstraint:
 		return rangeContains(r, v.Constraint)
 
+	case *preReleaseConstraint:
+		return rangeContains(r, v.Constraint)
+
+	case Constraints:
+		return v.c != nil && rangeContains(r, v.c)
+
+	case RangeSet:
+		for _, vr := range v {
+			if !rangeContainsRange(r, vr) {
+				return false
+			}
+		}
+		return true
+
 	case not:
 		return !rangeContainsRange(r, v.Range)
 
@@ -69,9 +133,169 @@ func rangeContains(r Range, other Constraint) bool {
 }
 
 func rangeContainsRange(rA, rB Range) bool {
-	if rA.Min.Compare(rB.Min) <= 0 &&
-		rA.Max.Compare(rB.Max) >= 0 {
+	if compareLower(rA.lower(), rB.lower()) <= 0 &&
+		compareUpper(rA.upper(), rB.upper()) >= 0 {
 		return true
 	}
 	return false
 }
+
+// greatest version number x.x.x.
+var maxVersion = Version{Major: maxUint64, Minor: maxUint64, Patch: maxUint64}
+
+// bound is the lower or upper end of a Range.
+type bound struct {
+	v         Version
+	exclusive bool
+	unbounded bool
+	upper     bool // false = lower bound, true = upper bound
+}
+
+// lower returns the lower bound of the range.
+func (r *Range) lower() bound {
+	return bound{v: r.Min, exclusive: r.MinExclusive, unbounded: r.MinUnbounded}
+}
+
+// upper returns the upper bound of the range.
+// An inclusive x.x.x upper bound is unbounded, as no version can be greater.
+func (r *Range) upper() bound {
+	return bound{
+		v: r.Max, exclusive: r.MaxExclusive, upper: true,
+		unbounded: r.MaxUnbounded || !r.MaxExclusive && r.Max.Same(maxVersion),
+	}
+}
+
+func (r *Range) setLower(b bound) {
+	r.Min, r.MinExclusive, r.MinUnbounded = b.v, b.exclusive, b.unbounded
+}
+
+func (r *Range) setUpper(b bound) {
+	r.Max, r.MaxExclusive, r.MaxUnbounded = b.v, b.exclusive, b.unbounded
+}
+
+// canonical returns the equivalent bound, that is inclusive on the lower
+// and exclusive on the upper end, if the version is a release with a successor,
+// e.g. >1.2.3 := >=1.2.4-0 and <=1.2.x := <1.3.0-0.
+// >=0.0.0-0 is unbounded, as no version is lower.
+func (b bound) canonical() bound {
+	if b.unbounded || b.exclusive == b.upper {
+		if !b.unbounded && !b.upper && b.v.Compare(minVersion) == 0 {
+			b.unbounded = true
+		}
+		return b
+	}
+	if next, ok := successor(b.v); ok {
+		b.v, b.exclusive = next, b.upper
+	}
+	return b
+}
+
+// String returns the bound as comparison, e.g. >=1.2.3 or <2.0.0.
+func (b bound) String() string {
+	op := ">"
+	if b.upper {
+		op = "<"
+	}
+	if !b.exclusive {
+		op += "="
+	}
+	return op + b.v.String()
+}
+
+// allows checks whether the version is on the inside of the bound.
+func (b bound) allows(v Version) bool {
+	if b.unbounded {
+		return true
+	}
+	d := v.Compare(b.v)
+	if b.upper {
+		d = -d
+	}
+	return d > 0 || d == 0 && !b.exclusive
+}
+
+// compareLower compares two lower bounds.
+// It returns -1, 0, or 1 if the bound a starts before, with, or after b.
+func compareLower(a, b bound) int {
+	a, b = a.canonical(), b.canonical()
+	switch {
+	case a.unbounded && b.unbounded:
+		return 0
+	case a.unbounded:
+		return -1
+	case b.unbounded:
+		return 1
+	}
+	if d := a.v.Compare(b.v); d != 0 {
+		return d
+	}
+	switch {
+	case a.exclusive == b.exclusive:
+		return 0
+	case a.exclusive:
+		return 1
+	}
+	return -1
+}
+
+// compareUpper compares two upper bounds.
+// It returns -1, 0, or 1 if the bound a ends before, with, or after b.
+func compareUpper(a, b bound) int {
+	a, b = a.canonical(), b.canonical()
+	switch {
+	case a.unbounded && b.unbounded:
+		return 0
+	case a.unbounded:
+		return 1
+	case b.unbounded:
+		return -1
+	}
+	if d := a.v.Compare(b.v); d != 0 {
+		return d
+	}
+	switch {
+	case a.exclusive == b.exclusive:
+		return 0
+	case a.exclusive:
+		return -1
+	}
+	return 1
+}
+
+// boundsOverlap checks whether a version exists that is within both
+// the lower bound and the upper bound.
+func boundsOverlap(lower, upper bound) bool {
+	lower, upper = lower.canonical(), upper.canonical()
+	if !upper.unbounded && upper.exclusive && upper.v.Compare(minVersion) == 0 {
+		// <0.0.0-0
+		return false
+	}
+	if lower.unbounded || upper.unbounded {
+		return true
+	}
+	d := lower.v.Compare(upper.v)
+	return d < 0 || d == 0 && !lower.exclusive && !upper.exclusive
+}
+
+// boundsConnect checks whether a range ending with the upper bound and
+// a range starting with the lower bound leave no gap in between,
+// e.g. <1.2.0 and >=1.2.0.
+func boundsConnect(upper, lower bound) bool {
+	if boundsOverlap(lower, upper) {
+		return true
+	}
+	upper, lower = upper.canonical(), lower.canonical()
+	return upper.v.Compare(lower.v) == 0 && upper.exclusive != lower.exclusive
+}
+
+// isSingleVersion returns true if the range only contains a single version: =1.2.3.
+func (r *Range) isSingleVersion() bool {
+	return !r.MinUnbounded && !r.MaxUnbounded &&
+		!r.MinExclusive && !r.MaxExclusive &&
+		r.Min.Same(r.Max)
+}
+
+// isEmpty returns true if no version can satisfy the range.
+func (r *Range) isEmpty() bool {
+	return !boundsOverlap(r.lower(), r.upper())
+}
@@ -38,6 +38,42 @@ func TestRange_Test(t *testing.T) {
 			v:        MustNewVersion("2.1.0"),
 			expected: false,
 		},
+		{
+			name:     ">1.0.0 <2.0.0 does not contain 1.0.0",
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0"), MinExclusive: true, MaxExclusive: true},
+			v:        MustNewVersion("1.0.0"),
+			expected: false,
+		},
+		{
+			name:     ">1.0.0 <2.0.0 does not contain 2.0.0",
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0"), MinExclusive: true, MaxExclusive: true},
+			v:        MustNewVersion("2.0.0"),
+			expected: false,
+		},
+		{
+			name:     ">1.0.0 <2.0.0 contains 2.0.0-rc.1",
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0"), MinExclusive: true, MaxExclusive: true},
+			v:        MustNewVersion("2.0.0-rc.1"),
+			expected: true,
+		},
+		{
+			name:     "<2.0.0 contains 0.0.0-0",
+			r:        Range{Max: MustNewVersion("2.0.0"), MinUnbounded: true, MaxExclusive: true},
+			v:        MustNewVersion("0.0.0-0"),
+			expected: true,
+		},
+		{
+			name:     ">=1.0.0 contains x.x.x",
+			r:        Range{Min: MustNewVersion("1.0.0"), MaxUnbounded: true},
+			v:        maxVersion,
+			expected: true,
+		},
+		{
+			name:     "unbounded Max version is ignored",
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("1.1.0"), MaxUnbounded: true},
+			v:        MustNewVersion("5.0.0"),
+			expected: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -48,6 +84,66 @@ func TestRange_Test(t *testing.T) {
 	}
 }
 
+func TestRange_String(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		r        Range
+		expected string
+	}{
+		{r: Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0")}, expected: "1.0.0 - 2.0.0"},
+		{r: Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("1.0.0")}, expected: "=1.0.0"},
+		{
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0"), MaxExclusive: true},
+			expected: ">=1.0.0 <2.0.0",
+		},
+		{
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0"), MinExclusive: true},
+			expected: ">1.0.0 <=2.0.0",
+		},
+		{r: Range{Min: MustNewVersion("1.0.0"), MinExclusive: true, MaxUnbounded: true}, expected: ">1.0.0"},
+		{r: Range{Max: MustNewVersion("2.0.0"), MinUnbounded: true}, expected: "<=2.0.0"},
+		{r: Range{MinUnbounded: true, MaxUnbounded: true}, expected: "*"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, test.r.String())
+		})
+	}
+}
+
+func TestRange_Interval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		r        Range
+		expected string
+	}{
+		{r: Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0")}, expected: "[1.0.0,2.0.0]"},
+		{r: Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("1.0.0")}, expected: "[1.0.0]"},
+		{
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0"), MaxExclusive: true},
+			expected: "[1.0.0,2.0.0)",
+		},
+		{
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("2.0.0"), MinExclusive: true},
+			expected: "(1.0.0,2.0.0]",
+		},
+		{
+			r:        Range{Min: MustNewVersion("1.0.0"), Max: MustNewVersion("1.0.0"), MinExclusive: true},
+			expected: "(1.0.0,1.0.0]",
+		},
+		{r: Range{Min: MustNewVersion("1.0.0"), MinExclusive: true, MaxUnbounded: true}, expected: "(1.0.0,)"},
+		{r: Range{Max: MustNewVersion("2.0.0"), MinUnbounded: true}, expected: "(,2.0.0]"},
+		{r: Range{MinUnbounded: true, MaxUnbounded: true}, expected: "(,)"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, test.r.Interval())
+		})
+	}
+}
+
 //nolint:maintidx // Table-driven test with many edge cases
 func TestRange_Contains(t *testing.T) {
 	t.Parallel()
@@ -313,6 +409,38 @@ func TestRange_Contains(t *testing.T) {
 			rB:       MustNewConstraint("^1.2.0 || ^1.5.0"),
 			expected: true,
 		},
+
+		// Edge cases: exclusive and unbounded bounds
+		{
+			name:     "<2.0.0 does contain <2.0.0-rc.1",
+			rA:       MustNewConstraint("<2.0.0"),
+			rB:       &Range{Max: MustNewVersion("2.0.0-rc.1"), MinUnbounded: true, MaxExclusive: true},
+			expected: true,
+		},
+		{
+			name:     "<2.0.0 does NOT contain <=2.0.0",
+			rA:       MustNewConstraint("<2.0.0"),
+			rB:       MustNewConstraint("<=2.0.0"),
+			expected: false,
+		},
+		{
+			name:     ">1.0.0 does NOT contain 1.0.0 - 2.0.0",
+			rA:       MustNewConstraint(">1.0.0"),
+			rB:       MustNewConstraint("1.0.0 - 2.0.0"),
+			expected: false,
+		},
+		{
+			name:     ">=1.0.0 does contain >1.0.0",
+			rA:       MustNewConstraint(">=1.0.0"),
+			rB:       MustNewConstraint(">1.0.0"),
+			expected: true,
+		},
+		{
+			name:     "1 - x does contain >=1.0.0",
+			rA:       MustNewConstraint("1 - x"),
+			rB:       MustNewConstraint(">=1.0.0"),
+			expected: true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
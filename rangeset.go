@@ -0,0 +1,96 @@
+package semver
+
+import (
+	"sort"
+	"strings"
+)
+
+// RangeSet is the canonical form of a Constraint:
+// an ascending ordered list of disjoint Ranges.
+// Overlapping and adjacent Ranges are always merged,
+// so two RangeSets allowing the same versions are the same.
+// Bounds keep their input form and are compared by the versions they allow:
+// >1.2.3 is the same bound as >=1.2.4-0 and <=1.2.3 || >=1.2.4-0 is merged into *.
+//
+// An empty RangeSet allows no version.
+//
+// RangeSets can not express pre-release policies. Normalize, and with it Equal,
+// Intersect, Union, Complement and Contains, ignore the PreReleaseExplicit policy
+// and treat every pre-release within a range as allowed.
+type RangeSet []Range
+
+var _ Constraint = RangeSet{}
+
+// Normalize flattens any Constraint into its RangeSet.
+// The pre-release policy of the Constraint is dropped, see RangeSet.
+// Constraint implementations outside of this package are not supported
+// and result in an empty RangeSet.
+func Normalize(c Constraint) RangeSet {
+	return constraintRanges(c)
+}
+
+// Equal tests if both Constraints allow exactly the same versions,
+// ignoring pre-release policies: >=1.2.0 with PreReleaseExplicit equals >=1.2.0,
+// even though only the latter allows 1.3.0-rc.1.
+func Equal(a, b Constraint) bool {
+	return Normalize(a).Same(Normalize(b))
+}
+
+// Same returns true if both RangeSets contain the same Ranges.
+func (s RangeSet) Same(o RangeSet) bool {
+	if len(s) != len(o) {
+		return false
+	}
+	for i := range s {
+		if !s[i].Same(o[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty returns true if the RangeSet allows no version.
+func (s RangeSet) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// Check if the version is allowed by the RangeSet.
+func (s RangeSet) Check(v Version) bool {
+	// first range with max >= v
+	i := sort.Search(len(s), func(i int) bool {
+		return s[i].upper().allows(v)
+	})
+	return i < len(s) && s[i].Check(v)
+}
+
+// Contains checks if all versions allowed by the other Constraint are in the RangeSet.
+func (s RangeSet) Contains(other Constraint) bool {
+	for _, r := range Normalize(other) {
+		// ranges are merged, so r has to be fully contained in a single range.
+		i := sort.Search(len(s), func(i int) bool {
+			return compareUpper(s[i].upper(), r.upper()) >= 0
+		})
+		if i == len(s) || !rangeContainsRange(s[i], r) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns all Ranges joined by " || ".
+func (s RangeSet) String() string {
+	parts := make([]string, len(s))
+	for i := range s {
+		parts[i] = s[i].String()
+	}
+	return strings.Join(parts, " || ")
+}
+
+// converts the RangeSet into a logical OR of its Ranges.
+func (s RangeSet) or() or {
+	out := make(or, len(s))
+	for i := range s {
+		out[i] = &s[i]
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected RangeSet
+	}{
+		{
+			input: "1.0.0 - 2.0.0",
+			expected: RangeSet{
+				{Min: Version{Major: 1}, Max: Version{Major: 2}},
+			},
+		},
+		{
+			input: "3 - 4 || 1.0.0 - 2.0.0 || >2.0.0 <=2.5.0",
+			expected: RangeSet{
+				{Min: Version{Major: 1}, Max: Version{Major: 2, Minor: 5}},
+				{Min: Version{Major: 3}, Max: Version{Major: 4}},
+			},
+		},
+		{
+			input: "1 - 3 && !=2.0.0",
+			expected: RangeSet{
+				{Min: Version{Major: 1}, Max: Version{Major: 2}, MaxExclusive: true},
+				{Min: Version{Major: 2}, Max: Version{Major: 3}, MinExclusive: true},
+			},
+		},
+		{
+			input: "<1.2.3 || >=2 || ~1.2",
+			expected: RangeSet{
+				{MinUnbounded: true, Max: Version{Major: 1, Minor: 2, Patch: maxUint64}},
+				{Min: Version{Major: 2}, MaxUnbounded: true},
+			},
+		},
+		{
+			input: "x - x",
+			expected: RangeSet{
+				{MaxUnbounded: true},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			c := MustNewConstraint(test.input)
+			rs := Normalize(c)
+			assert.Equal(t, test.expected, rs)
+			assert.True(t, Equal(c, rs))
+
+			for _, v := range []string{"0.1.0", "1.0.0", "1.5.0", "2.0.0-rc.1", "2.0.0", "2.0.1", "2.5.1", "3.5.0", "4.0.1"} {
+				version := MustNewVersion(v)
+				assert.Equal(t, c.Check(version), rs.Check(version), v)
+			}
+		})
+	}
+}
+
+func TestNormalize_unknown(t *testing.T) {
+	t.Parallel()
+	assert.True(t, Normalize(&positiveConstraint{}).IsEmpty())
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "~1.2", b: "=1.2.x", expected: true},
+		{a: "^1.2", b: "1.2 - 1.x", expected: true},
+		{a: ">=1.2.0", b: "1.2 - x", expected: true},
+		{a: "1 - 2 || 2 - 3", b: "1 - 3", expected: true},
+		{a: "<1.2.0 || 1.2.0 - 2.0.0", b: "<=2", expected: true},
+		{a: "!=2.0.0", b: "<2.0.0 || >2.0.0", expected: true},
+		// bounds next to a release equal the bounds of its successor.
+		{a: ">1.2.3", b: ">=1.2.4-0", expected: true},
+		{a: "<=1.2.3", b: "<1.2.4-0", expected: true},
+		{a: ">1.x", b: ">=2.0.0-0", expected: true},
+		{a: "<=1.2.3 || >=1.2.4-0", b: ">=0.0.0-0", expected: true},
+		{a: ">1.2.3-rc.1", b: ">=1.2.3-rc.2", expected: false},
+		{a: "^1.2", b: "~1.2", expected: false},
+		{a: "!=2", b: "!=3", expected: false},
+		// 2.0.0-rc.1 is < 2.0.0, but not in 1.x.x
+		{a: "^1.2", b: ">=1.2.0 <2", expected: false},
+		// 1.2.0-rc.1 is in 1 - 2, but not in any other range
+		{a: "1.0.0 - 1.1.x || 1.2.0 - 2.0.0", b: "1 - 2", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.a+" == "+test.b, func(t *testing.T) {
+			t.Parallel()
+			a := MustNewConstraint(test.a)
+			b := MustNewConstraint(test.b)
+			assert.Equal(t, test.expected, Equal(a, b))
+			assert.Equal(t, test.expected, Equal(b, a))
+		})
+	}
+}
+
+// RangeSets can not express pre-release policies.
+func TestEqual_ignoresPreReleasePolicy(t *testing.T) {
+	t.Parallel()
+	explicit := MustNewConstraint(">=1.2.0", PreReleaseExplicit)
+	include := MustNewConstraint(">=1.2.0")
+	v := MustNewVersion("1.3.0-rc.1")
+
+	assert.False(t, explicit.Check(v))
+	assert.True(t, include.Check(v))
+	assert.True(t, Equal(explicit, include))
+	assert.True(t, Normalize(explicit).Check(v))
+}
+
+func TestRangeSet_Contains(t *testing.T) {
+	t.Parallel()
+	rs := Normalize(MustNewConstraint("1 - 2 || 4 - 5"))
+
+	assert.True(t, rs.Contains(MustNewConstraint("1.2 - 1.5")))
+	assert.True(t, rs.Contains(MustNewConstraint("1.2 - 1.5 || 4.1 - 4.2")))
+	assert.True(t, rs.Contains(rs))
+	assert.False(t, rs.Contains(MustNewConstraint("1.2 - 4.2")))
+	assert.False(t, rs.Contains(MustNewConstraint("!=1.2.3")))
+
+	assert.True(t, MustNewConstraint("1 - 5").Contains(rs))
+	assert.True(t, MustNewConstraint("1 - 2 || 4 - 5").Contains(rs))
+	assert.False(t, MustNewConstraint("1 - 2").Contains(rs))
+	assert.True(t, MustNewConstraint("!=3").Contains(rs))
+	assert.False(t, MustNewConstraint("!=4.5.0").Contains(rs))
+}
+
+func TestRangeSet_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "1.0.0 - 2.0.0 || =4.0.0", Normalize(MustNewConstraint("1 - 2 || =4.0.0")).String())
+	assert.Empty(t, RangeSet{}.String())
+}
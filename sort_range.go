@@ -17,7 +17,7 @@ func (l AscendingMin) Len() int {
 // Returns true if item[i] should sort before item[j] (descending order).
 // Implements sort.Interface.
 func (l AscendingMin) Less(i, j int) bool {
-	return l[i].Min.LessThan(l[j].Min)
+	return compareLower(l[i].lower(), l[j].lower()) < 0
 }
 
 // Swaps the position of two items in the list.
@@ -41,7 +41,7 @@ func (l AscendingMax) Len() int {
 // Returns true if item[i] should sort before item[j] (descending order).
 // Implements sort.Interface.
 func (l AscendingMax) Less(i, j int) bool {
-	return l[i].Max.LessThan(l[j].Max)
+	return compareUpper(l[i].upper(), l[j].upper()) < 0
 }
 
 // Swaps the position of two items in the list.
@@ -65,7 +65,7 @@ func (l DescendingMin) Len() int {
 // Returns true if item[i] should sort before item[j] (descending order).
 // Implements sort.Interface.
 func (l DescendingMin) Less(i, j int) bool {
-	return l[i].Min.GreaterThan(l[j].Min)
+	return compareLower(l[i].lower(), l[j].lower()) > 0
 }
 
 // Swaps the position of two items in the list.
@@ -89,7 +89,7 @@ func (l DescendingMax) Len() int {
 // Returns true if item[i] should sort before item[j] (descending order).
 // Implements sort.Interface.
 func (l DescendingMax) Less(i, j int) bool {
-	return l[i].Max.GreaterThan(l[j].Max)
+	return compareUpper(l[i].upper(), l[j].upper()) > 0
 }
 
 // Swaps the position of two items in the list.
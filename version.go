@@ -1,6 +1,8 @@
 package semver
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"strconv"
@@ -41,11 +43,17 @@ func (v *Version) Same(o Version) bool {
 }
 
 // String returns a string representation of the Version.
+// Segments holding the max uint64 value are printed as x wildcard, e.g. 1.x.x.
 func (v *Version) String() string {
+	return v.format(printXonMaxInt)
+}
+
+// format prints the Version using the given function to print major, minor and patch.
+func (v *Version) format(segment func(uint64) string) string {
 	s := fmt.Sprintf("%s.%s.%s",
-		printXonMaxInt(v.Major),
-		printXonMaxInt(v.Minor),
-		printXonMaxInt(v.Patch))
+		segment(v.Major),
+		segment(v.Minor),
+		segment(v.Patch))
 	if len(v.PreRelease) > 0 {
 		s += "-" + v.PreRelease.String()
 	}
@@ -55,6 +63,52 @@ func (v *Version) String() string {
 	return s
 }
 
+var (
+	_ encoding.TextMarshaler   = Version{}
+	_ encoding.TextUnmarshaler = (*Version)(nil)
+	_ json.Marshaler           = Version{}
+	_ json.Unmarshaler         = (*Version)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler.
+// Unlike String, max uint64 segments are encoded as number to round-trip via UnmarshalText.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.format(formatUint)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Version) UnmarshalText(text []byte) error {
+	nv, _, err := parseVersion(text)
+	if err != nil {
+		return err
+	}
+	*v = nv
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// Versions are encoded as JSON string.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.format(formatUint))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Versions are decoded from a JSON string, null is a no-op.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+func formatUint(d uint64) string {
+	return strconv.FormatUint(d, 10)
+}
+
 func printXonMaxInt(d uint64) string {
 	if d == maxUint64 {
 		return "x"
@@ -79,6 +133,8 @@ func (v *Version) GreaterThan(o Version) bool {
 
 // Compare compares this version to another one. It returns -1, 0, or 1 if
 // the version smaller, equal, or larger than the other version.
+// Pre-releases follow the SemVer precedence rules, a pre-release with additional
+// identifiers is larger: 1.0.0-rc < 1.0.0-rc.0 < 1.0.0-rc.1 < 1.0.0.
 func (v *Version) Compare(o Version) int {
 	if d := compareSegment(v.Major, o.Major); d != 0 {
 		return d
@@ -128,17 +184,15 @@ func (l PreReleaseIdentifierList) Compare(o []PreReleaseIdentifier) int {
 	}
 
 	for i := range prel {
-		var (
-			pre   PreReleaseIdentifier
-			other PreReleaseIdentifier
-		)
-		if i < preLen {
-			pre = l[i]
+		// A larger set of pre-release fields has a higher precedence
+		// than a smaller set, if all of the preceding identifiers are equal.
+		if i >= preLen {
+			return 1
 		}
-		if i < otherLen {
-			other = o[i]
+		if i >= otherLen {
+			return -1
 		}
-		if d := pre.Compare(other); d != 0 {
+		if d := l[i].Compare(o[i]); d != 0 {
 			return d
 		}
 	}
@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -108,6 +109,16 @@ func TestPreReleaseIdentifierList_Compare(t *testing.T) {
 			},
 			expected: 1,
 		},
+		{
+			name: "rc before rc.0",
+			pre: []PreReleaseIdentifier{
+				toPR("rc"),
+			},
+			other: []PreReleaseIdentifier{
+				toPR("rc"), toPR("0"),
+			},
+			expected: 1,
+		},
 		{
 			name: "beta before beta.11",
 			pre: []PreReleaseIdentifier{
@@ -150,3 +161,87 @@ func TestPreReleaseIdentifierList_Compare(t *testing.T) {
 		})
 	}
 }
+
+func TestVersion_Compare(t *testing.T) {
+	t.Parallel()
+	// ascending precedence following SemVer 2.0.0 section 11.
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc", "1.0.0-rc.0", "1.0.0-rc.1", "1.0.0",
+	}
+	for i := range len(ordered) - 1 {
+		a, b := MustNewVersion(ordered[i]), MustNewVersion(ordered[i+1])
+		assert.Equal(t, -1, a.Compare(b), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", ordered[i+1], ordered[i])
+		assert.Equal(t, 0, a.Compare(a), "%s = %s", ordered[i], ordered[i])
+	}
+}
+
+func TestVersion_Text(t *testing.T) {
+	t.Parallel()
+	tests := []string{
+		"0.0.4", "1.1.2-prerelease+meta", "1.0.0-alpha.beta.1",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12+788",
+		"18446744073709551615.18446744073709551615.18446744073709551615",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			t.Parallel()
+			v := MustNewVersion(test)
+			text, err := v.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, test, string(text))
+
+			var out Version
+			require.NoError(t, out.UnmarshalText(text))
+			assert.Equal(t, v, out)
+		})
+	}
+}
+
+func TestVersion_JSON(t *testing.T) {
+	t.Parallel()
+	type obj struct {
+		Version  Version            `json:"version"`
+		Pointer  *Version           `json:"pointer,omitempty"`
+		Versions map[string]Version `json:"versions,omitempty"`
+	}
+
+	in := obj{
+		Version:  MustNewVersion("1.2.3-rc.1+meta"),
+		Versions: map[string]Version{"a": MustNewVersion("2.0.0")},
+	}
+	j, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"1.2.3-rc.1+meta","versions":{"a":"2.0.0"}}`, string(j))
+
+	var out obj
+	require.NoError(t, json.Unmarshal(j, &out))
+	assert.Equal(t, in, out)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"version":null}`), &out))
+	assert.Equal(t, in.Version, out.Version)
+	// max uint64 is not printed as x wildcard.
+	maxVersion := MustNewVersion("18446744073709551615.0.0")
+	j, err = json.Marshal(maxVersion)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"18446744073709551615.0.0"`, string(j))
+	var maxOut Version
+	require.NoError(t, json.Unmarshal(j, &maxOut))
+	assert.Equal(t, maxVersion, maxOut)
+}
+
+func TestVersion_UnmarshalJSON_error(t *testing.T) {
+	t.Parallel()
+	var v Version
+	err := json.Unmarshal([]byte(`"1.2"`), &v)
+	require.EqualError(t, err, "col 4: missing patch")
+
+	err = json.Unmarshal([]byte(`123`), &v)
+	require.Error(t, err)
+
+	// max uint64 plus one.
+	err = json.Unmarshal([]byte(`"18446744073709551616.0.0"`), &v)
+	require.ErrorIs(t, err, ErrOverflow)
+	require.EqualError(t, err, "col 1: number 18446744073709551616 too large")
+}
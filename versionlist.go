@@ -0,0 +1,115 @@
+package semver
+
+import "slices"
+
+// Filter returns the versions satisfying the constraint in order.
+func (l VersionList) Filter(c Constraint) VersionList {
+	var out VersionList
+	for _, v := range l {
+		if c.Check(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Max returns the highest version, or false if the list is empty.
+// Of versions with the same precedence, e.g. 1.2.3+a and 1.2.3+b, the first is returned.
+func (l VersionList) Max() (Version, bool) {
+	if len(l) == 0 {
+		return Version{}, false
+	}
+	return slices.MaxFunc(l, compareVersions), true
+}
+
+// Min returns the lowest version, or false if the list is empty.
+// Of versions with the same precedence, e.g. 1.2.3+a and 1.2.3+b, the first is returned.
+func (l VersionList) Min() (Version, bool) {
+	if len(l) == 0 {
+		return Version{}, false
+	}
+	return slices.MinFunc(l, compareVersions), true
+}
+
+// MaxSatisfying returns the highest version satisfying the constraint,
+// or false if there is none.
+func (l VersionList) MaxSatisfying(c Constraint) (Version, bool) {
+	return l.maxFunc(c.Check)
+}
+
+// LatestStable returns the highest version without pre-release,
+// or false if there is none.
+func (l VersionList) LatestStable() (Version, bool) {
+	return l.maxFunc(func(v Version) bool { return len(v.PreRelease) == 0 })
+}
+
+// maxFunc returns the first highest version for which keep returns true.
+func (l VersionList) maxFunc(keep func(Version) bool) (Version, bool) {
+	var (
+		highest Version
+		found   bool
+	)
+	for _, v := range l {
+		if keep(v) && (!found || v.GreaterThan(highest)) {
+			highest, found = v, true
+		}
+	}
+	return highest, found
+}
+
+// Dedup returns the list without versions of the same precedence in order,
+// keeping the first occurrence: 1.2.3+a, 1.2.3+b, 1.2.3 => 1.2.3+a.
+func (l VersionList) Dedup() VersionList {
+	return l.dedupBy(func(v Version) string {
+		v.BuildMetadata = nil
+		return v.String()
+	})
+}
+
+// DedupSame returns the list without versions that are the Same in order,
+// keeping the first occurrence: 1.2.3+a, 1.2.3+b, 1.2.3+a => 1.2.3+a, 1.2.3+b.
+func (l VersionList) DedupSame() VersionList {
+	return l.dedupBy(func(v Version) string { return v.String() })
+}
+
+func (l VersionList) dedupBy(key func(Version) string) VersionList {
+	seen := make(map[string]struct{}, len(l))
+	var out VersionList
+	for _, v := range l {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// MajorMinor identifies a minor release line, e.g. 1.2 for 1.2.0 and 1.2.3.
+type MajorMinor struct {
+	Major, Minor uint64
+}
+
+// GroupByMajor returns the versions grouped by major version in order.
+func (l VersionList) GroupByMajor() map[uint64]VersionList {
+	groups := map[uint64]VersionList{}
+	for _, v := range l {
+		groups[v.Major] = append(groups[v.Major], v)
+	}
+	return groups
+}
+
+// GroupByMajorMinor returns the versions grouped by major.minor version in order.
+func (l VersionList) GroupByMajorMinor() map[MajorMinor]VersionList {
+	groups := map[MajorMinor]VersionList{}
+	for _, v := range l {
+		key := MajorMinor{Major: v.Major, Minor: v.Minor}
+		groups[key] = append(groups[key], v)
+	}
+	return groups
+}
+
+func compareVersions(a, b Version) int {
+	return a.Compare(b)
+}
@@ -0,0 +1,120 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newVersionList(versions ...string) VersionList {
+	l := make(VersionList, len(versions))
+	for i, v := range versions {
+		l[i] = MustNewVersion(v)
+	}
+	return l
+}
+
+func TestVersionList_Filter(t *testing.T) {
+	t.Parallel()
+	l := newVersionList("1.1.0", "1.2.0", "1.3.0", "1.2.7", "2.0.0")
+	assert.Equal(t, "1.2.0, 1.2.7", l.Filter(MustNewConstraint("~1.2")).String())
+	assert.Empty(t, l.Filter(MustNewConstraint("^3")))
+}
+
+func TestVersionList_MaxMin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		list     VersionList
+		max, min string
+	}{
+		{
+			name: "mixed",
+			list: newVersionList("1.2.0", "1.10.0", "1.10.0-rc.1", "0.9.0", "1.2.0-beta.2"),
+			max:  "1.10.0",
+			min:  "0.9.0",
+		},
+		{
+			name: "same precedence returns first",
+			list: newVersionList("1.2.3+build.1", "1.2.3+build.2"),
+			max:  "1.2.3+build.1",
+			min:  "1.2.3+build.1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			maxV, ok := test.list.Max()
+			assert.True(t, ok)
+			assert.Equal(t, test.max, maxV.String())
+			minV, ok := test.list.Min()
+			assert.True(t, ok)
+			assert.Equal(t, test.min, minV.String())
+		})
+	}
+
+	_, ok := VersionList{}.Max()
+	assert.False(t, ok)
+	_, ok = VersionList{}.Min()
+	assert.False(t, ok)
+}
+
+func TestVersionList_MaxSatisfying(t *testing.T) {
+	t.Parallel()
+	l := newVersionList("1.2.0", "1.9.1", "2.0.0", "1.10.0-rc.1")
+	tests := []struct {
+		constraint string
+		expected   string
+		found      bool
+	}{
+		{constraint: "^1.2", expected: "1.10.0-rc.1", found: true},
+		{constraint: "~1.2", expected: "1.2.0", found: true},
+		{constraint: ">=2", expected: "2.0.0", found: true},
+		{constraint: "^3", found: false},
+	}
+	for _, test := range tests {
+		t.Run(test.constraint, func(t *testing.T) {
+			t.Parallel()
+			v, ok := l.MaxSatisfying(MustNewConstraint(test.constraint))
+			assert.Equal(t, test.found, ok)
+			if test.found {
+				assert.Equal(t, test.expected, v.String())
+			}
+		})
+	}
+}
+
+func TestVersionList_LatestStable(t *testing.T) {
+	t.Parallel()
+	v, ok := newVersionList("1.2.0", "2.0.0-rc.1", "1.9.1", "1.10.0-rc.1").LatestStable()
+	assert.True(t, ok)
+	assert.Equal(t, "1.9.1", v.String())
+
+	_, ok = newVersionList("2.0.0-rc.1").LatestStable()
+	assert.False(t, ok)
+}
+
+func TestVersionList_Dedup(t *testing.T) {
+	t.Parallel()
+	l := newVersionList("1.2.3+build.1", "1.0.0", "1.2.3+build.2", "1.2.3", "1.2.3+build.1", "1.0.0-rc.1")
+	assert.Equal(t, "1.2.3+build.1, 1.0.0, 1.0.0-rc.1", l.Dedup().String())
+	assert.Equal(t, "1.2.3+build.1, 1.0.0, 1.2.3+build.2, 1.2.3, 1.0.0-rc.1", l.DedupSame().String())
+	// the list itself is not modified.
+	assert.Len(t, l, 6)
+	assert.Equal(t, "1.2.3+build.1", l[0].String())
+}
+
+func TestVersionList_Group(t *testing.T) {
+	t.Parallel()
+	l := newVersionList("1.2.0", "2.0.0", "1.2.3", "1.3.0", "0.1.0")
+
+	byMajor := l.GroupByMajor()
+	assert.Len(t, byMajor, 3)
+	assert.Equal(t, "1.2.0, 1.2.3, 1.3.0", byMajor[1].String())
+	assert.Equal(t, "2.0.0", byMajor[2].String())
+
+	byMinor := l.GroupByMajorMinor()
+	assert.Len(t, byMinor, 4)
+	assert.Equal(t, "1.2.0, 1.2.3", byMinor[MajorMinor{Major: 1, Minor: 2}].String())
+	assert.Equal(t, "0.1.0", byMinor[MajorMinor{Major: 0, Minor: 1}].String())
+}
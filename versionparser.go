@@ -1,8 +1,6 @@
 package semver
 
 import (
-	"errors"
-	"fmt"
 	"strconv"
 	"unicode/utf8"
 
@@ -10,8 +8,8 @@ import (
 )
 
 // MustNewVersion parses the given string into a Version object and panics on error.
-func MustNewVersion(src string) Version {
-	v, err := NewVersion(src)
+func MustNewVersion(src string, opts ...ParseOption) Version {
+	v, err := NewVersion(src, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -19,20 +17,92 @@ func MustNewVersion(src string) Version {
 }
 
 // NewVersion parses the given string into a Version object.
-func NewVersion(src string) (Version, error) {
-	return parseVersion([]byte(src))
+// Parsing is strict by default, ParseOptions allow common deviations from semver.
+func NewVersion(src string, opts ...ParseOption) (Version, error) {
+	v, _, err := parseVersion([]byte(src), opts...)
+	return v, err
+}
+
+// NewVersionCoerced works like NewVersion and additionally reports,
+// if the input deviated from strict semver, e.g. v1.2 parsed with AllowVPrefix and FillMissing.
+func NewVersionCoerced(src string, opts ...ParseOption) (v Version, coerced bool, err error) {
+	return parseVersion([]byte(src), opts...)
+}
+
+// ParseOptions allow common deviations from semver when parsing versions.
+// NewVersionCoerced reports, if any of these deviations was used.
+type ParseOptions struct {
+	// AllowVPrefix accepts a leading v or V, e.g. v1.2.3.
+	AllowVPrefix bool
+	// FillMissing fills missing minor and patch numbers with 0,
+	// e.g. 1 => 1.0.0 and 1.2 => 1.2.0.
+	FillMissing bool
+	// AllowLeadingZeros accepts leading zeros in major, minor and patch,
+	// e.g. 01.02.03 => 1.2.3.
+	AllowLeadingZeros bool
+}
+
+// Lenient enables all ParseOptions.
+var Lenient = ParseOptions{
+	AllowVPrefix:      true,
+	FillMissing:       true,
+	AllowLeadingZeros: true,
+}
+
+// ApplyToParseOptions implements ParseOption.
+// Only enabled options are applied.
+func (o ParseOptions) ApplyToParseOptions(opts *ParseOptions) {
+	opts.AllowVPrefix = opts.AllowVPrefix || o.AllowVPrefix
+	opts.FillMissing = opts.FillMissing || o.FillMissing
+	opts.AllowLeadingZeros = opts.AllowLeadingZeros || o.AllowLeadingZeros
+}
+
+// ParseOption can be passed to NewVersion.
+type ParseOption interface {
+	ApplyToParseOptions(opts *ParseOptions)
+}
+
+// AllowVPrefix accepts a leading v or V, e.g. v1.2.3.
+type AllowVPrefix struct{}
+
+// ApplyToParseOptions implements ParseOption.
+func (AllowVPrefix) ApplyToParseOptions(opts *ParseOptions) {
+	opts.AllowVPrefix = true
+}
+
+// FillMissing fills missing minor and patch numbers with 0,
+// e.g. 1 => 1.0.0 and 1.2 => 1.2.0.
+type FillMissing struct{}
+
+// ApplyToParseOptions implements ParseOption.
+func (FillMissing) ApplyToParseOptions(opts *ParseOptions) {
+	opts.FillMissing = true
+}
+
+// AllowLeadingZeros accepts leading zeros in major, minor and patch,
+// e.g. 01.02.03 => 1.2.3.
+type AllowLeadingZeros struct{}
+
+// ApplyToParseOptions implements ParseOption.
+func (AllowLeadingZeros) ApplyToParseOptions(opts *ParseOptions) {
+	opts.AllowLeadingZeros = true
 }
 
 // parse bytes into a Version.
-func parseVersion(src []byte) (Version, error) {
+func parseVersion(src []byte, opts ...ParseOption) (Version, bool, error) {
 	var p parser
+	for _, opt := range opts {
+		opt.ApplyToParseOptions(&p.opts)
+	}
 	p.init(src)
-	return p.parse()
+	v, err := p.parse()
+	return v, p.coerced, withInput(err, src)
 }
 
 type parser struct {
-	pos internal.Position // column number
-	src []byte
+	pos  internal.Position // column number
+	src  []byte
+	opts ParseOptions
 
 	// parser state
 	ch       rune // current character
@@ -44,6 +114,7 @@ type parser struct {
 	// 3 => Pre Release
 	// 4 => Build
 	logicalPosition int
+	coerced         bool // input deviated from strict semver
 }
 
 func (p *parser) init(src []byte) {
@@ -69,9 +140,9 @@ func (p *parser) next() error {
 	p.offset = p.rdOffset
 	switch p.ch {
 	case '\n':
-		return fmt.Errorf("%s: illegal character NEWLINE", p.pos)
+		return parseErrorf(p.pos, ErrInvalidCharacter, "illegal character NEWLINE")
 	case ' ':
-		return fmt.Errorf("%s: illegal character SPACE", p.pos)
+		return parseErrorf(p.pos, ErrInvalidCharacter, "illegal character SPACE")
 	default:
 		p.pos++
 	}
@@ -79,12 +150,12 @@ func (p *parser) next() error {
 	r, w := rune(p.src[p.rdOffset]), 1
 	switch {
 	case r == 0:
-		return fmt.Errorf("%s: illegal character NUL", p.pos-1)
+		return parseErrorf(p.pos-1, ErrInvalidCharacter, "illegal character NUL")
 
 	case r >= utf8.RuneSelf:
 		r, w = utf8.DecodeRune(p.src[p.rdOffset:])
 		if r == utf8.RuneError && w == 1 {
-			return fmt.Errorf("%s: illegal UTF-8 encoding", p.pos-1)
+			return parseErrorf(p.pos-1, ErrInvalidCharacter, "illegal UTF-8 encoding")
 		}
 	}
 	p.ch = r
@@ -96,6 +167,13 @@ func (p *parser) parse() (Version, error) {
 	// TODO add column number to errors
 	v := Version{}
 
+	if p.opts.AllowVPrefix && (p.ch == 'v' || p.ch == 'V') {
+		if err := p.next(); err != nil {
+			return v, err
+		}
+		p.coerced = true
+	}
+
 parser:
 	for {
 		// col = p.col
@@ -132,7 +210,7 @@ parser:
 				goto parser
 
 			default:
-				return Version{}, fmt.Errorf("%s: invalid character %q", p.pos, p.ch)
+				return Version{}, parseErrorf(p.pos, ErrInvalidCharacter, "invalid character %q", p.ch)
 			}
 		}
 
@@ -157,13 +235,23 @@ parser:
 		}
 	}
 
+	// 1. and 1.2. are incomplete, even when filling missing numbers.
+	trailingDot := len(p.src) > 0 && p.src[len(p.src)-1] == '.'
+	if p.opts.FillMissing && p.logicalPosition > 0 && p.logicalPosition < 3 && !trailingDot {
+		p.coerced = true
+		p.logicalPosition = 3
+	}
+
 	switch p.logicalPosition {
 	case 0:
-		return v, fmt.Errorf("%s: missing major", p.pos+1)
+		if len(p.src) == 0 {
+			return v, parseErrorf(p.pos+1, ErrEmptyInput, "missing major")
+		}
+		return v, parseErrorf(p.pos+1, ErrMissingSegment, "missing major")
 	case 1:
-		return v, fmt.Errorf("%s: missing minor", p.pos+1)
+		return v, parseErrorf(p.pos+1, ErrMissingSegment, "missing minor")
 	case 2:
-		return v, fmt.Errorf("%s: missing patch", p.pos+1)
+		return v, parseErrorf(p.pos+1, ErrMissingSegment, "missing patch")
 	}
 
 	return v, nil
@@ -171,7 +259,7 @@ parser:
 
 func (p *parser) scanDot() error {
 	if p.ch != '.' {
-		return fmt.Errorf("%s: invalid character %q", p.pos, p.ch)
+		return parseErrorf(p.pos, ErrInvalidCharacter, "invalid character %q", p.ch)
 	}
 	if err := p.next(); err != nil {
 		return err
@@ -189,12 +277,19 @@ func (p *parser) scanNumber(ch rune) (uint64, error) {
 	}
 	out := string(p.src[offs:p.offset])
 	if len(out) == 0 || out == "." {
-		return 0, fmt.Errorf("%s: expected number, got nothing", pos)
+		return 0, parseErrorf(pos, ErrMissingSegment, "expected number, got nothing")
 	}
 	if out != "0" && !isPositiveDigit(rune(out[0])) {
-		return 0, fmt.Errorf("%s: starts with non-positive integer %q", p.pos-1, ch)
+		if !p.opts.AllowLeadingZeros || out[0] != '0' {
+			return 0, parseErrorf(p.pos-1, ErrInvalidCharacter, "starts with non-positive integer %q", ch)
+		}
+		p.coerced = true
+	}
+	n, err := strconv.ParseUint(out, 10, 0)
+	if err != nil {
+		return 0, parseErrorf(pos-1, ErrOverflow, "number %s too large", out)
 	}
-	return strconv.ParseUint(out, 10, 0)
+	return n, nil
 }
 
 func (p *parser) scanBuildMeta() ([]string, error) {
@@ -207,10 +302,10 @@ func (p *parser) scanBuildMeta() ([]string, error) {
 		}
 		if len(s) == 0 {
 			// must be non-empty
-			return nil, fmt.Errorf("%s: build identifier empty", pos)
+			return nil, parseErrorf(pos, ErrInvalidIdentifier, "build identifier empty")
 		}
 		if !isBuildIdentifier(s) {
-			return nil, fmt.Errorf("%s: invalid build identifier %q", pos, s)
+			return nil, parseErrorf(pos, ErrInvalidIdentifier, "invalid build identifier %q", s)
 		}
 		prParts = append(prParts, s)
 		if end {
@@ -222,7 +317,7 @@ func (p *parser) scanBuildMeta() ([]string, error) {
 
 func (p *parser) scanPreRelease() ([]PreReleaseIdentifier, error) {
 	if p.logicalPosition != 3 {
-		return nil, errors.New("pre release not after patch")
+		return nil, parseErrorf(p.pos, ErrSyntax, "pre release not after patch")
 	}
 
 	var prParts []PreReleaseIdentifier
@@ -234,10 +329,10 @@ func (p *parser) scanPreRelease() ([]PreReleaseIdentifier, error) {
 		}
 		if len(s) == 0 {
 			// must be non-empty
-			return nil, fmt.Errorf("%s: pre release identifier empty", pos)
+			return nil, parseErrorf(pos, ErrInvalidIdentifier, "pre release identifier empty")
 		}
 		if !isPreReleaseIdentifier(s) {
-			return nil, fmt.Errorf("%s: invalid pre release identifier %q", pos, s)
+			return nil, parseErrorf(pos, ErrInvalidIdentifier, "invalid pre release identifier %q", s)
 		}
 		prParts = append(prParts, ToPreReleaseIdentifier(s))
 		if end {
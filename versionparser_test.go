@@ -270,7 +270,67 @@ func TestParser_invalidBytes(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
-		_, err := parseVersion(test.version)
+		_, _, err := parseVersion(test.version)
 		require.EqualError(t, err, test.expectedErr)
 	}
 }
+
+func TestParser_options(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version  string
+		opts     []ParseOption
+		expected string
+		coerced  bool
+	}{
+		{version: "1.2.3", opts: []ParseOption{Lenient}, expected: "1.2.3"},
+		{version: "1.2.3-rc.1+b1", opts: []ParseOption{Lenient}, expected: "1.2.3-rc.1+b1"},
+		{version: "v1.2.3", opts: []ParseOption{AllowVPrefix{}}, expected: "1.2.3", coerced: true},
+		{version: "V1.2.3-rc.1", opts: []ParseOption{AllowVPrefix{}}, expected: "1.2.3-rc.1", coerced: true},
+		{version: "1", opts: []ParseOption{FillMissing{}}, expected: "1.0.0", coerced: true},
+		{version: "1.2", opts: []ParseOption{FillMissing{}}, expected: "1.2.0", coerced: true},
+		{version: "v1.2", opts: []ParseOption{AllowVPrefix{}, FillMissing{}}, expected: "1.2.0", coerced: true},
+		{version: "01.02.03", opts: []ParseOption{AllowLeadingZeros{}}, expected: "1.2.3", coerced: true},
+		{version: "v2024.01", opts: []ParseOption{Lenient}, expected: "2024.1.0", coerced: true},
+		{version: "1.0.0", opts: []ParseOption{ParseOptions{FillMissing: true}}, expected: "1.0.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			t.Parallel()
+			v, coerced, err := NewVersionCoerced(test.version, test.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v.String())
+			assert.Equal(t, test.coerced, coerced)
+
+			nv, err := NewVersion(test.version, test.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, v, nv)
+			// coerced versions are equal to their strict counterparts.
+			assert.Equal(t, MustNewVersion(test.expected), v)
+		})
+	}
+}
+
+func TestParser_options_error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version     string
+		opts        []ParseOption
+		expectedErr string
+	}{
+		{version: "v1.2.3", expectedErr: `col 2: starts with non-positive integer 'v'`},
+		{version: "v1.2.3", opts: []ParseOption{FillMissing{}}, expectedErr: `col 2: starts with non-positive integer 'v'`},
+		{version: "v", opts: []ParseOption{AllowVPrefix{}}, expectedErr: `col 2: missing major`},
+		{version: "1.", opts: []ParseOption{FillMissing{}}, expectedErr: `col 3: missing minor`},
+		{version: "1.2.", opts: []ParseOption{FillMissing{}}, expectedErr: `col 5: missing patch`},
+		{version: "01.2.3", opts: []ParseOption{AllowVPrefix{}}, expectedErr: `col 2: starts with non-positive integer '0'`},
+		{version: "1.2.3-01", opts: []ParseOption{Lenient}, expectedErr: `col 7: invalid pre release identifier "01"`},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewVersion(test.version, test.opts...)
+			require.EqualError(t, err, test.expectedErr)
+		})
+	}
+}